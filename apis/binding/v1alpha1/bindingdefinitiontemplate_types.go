@@ -0,0 +1,86 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// BindingDefinitionTemplateSpec defines binding data exposed by services of a given kind
+type BindingDefinitionTemplateSpec struct {
+	// Group of the service kind the template applies to.  Empty for the core API group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Kind of services the template applies to.
+	Kind string `json:"kind"`
+
+	// Versions of the service kind the template applies to.  If empty, the
+	// template applies to all versions.
+	// +optional
+	Versions []string `json:"versions,omitempty"`
+
+	// Definitions declare binding data the same way service.binding
+	// annotations do, keyed by the annotation name, e.g.
+	// `service.binding/username: path={.spec.user}`.
+	// +kubebuilder:validation:MinProperties:=1
+	Definitions map[string]string `json:"definitions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.group`
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.kind`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// BindingDefinitionTemplate declares binding data of services whose
+// resources and CRDs cannot be annotated, e.g. services managed by third-party operators
+type BindingDefinitionTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec BindingDefinitionTemplateSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// BindingDefinitionTemplateList contains a list of BindingDefinitionTemplate
+type BindingDefinitionTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BindingDefinitionTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BindingDefinitionTemplate{}, &BindingDefinitionTemplateList{})
+}
+
+// Matches returns true if the template applies to services of the given group, version and kind
+func (spec *BindingDefinitionTemplateSpec) Matches(group string, version string, kind string) bool {
+	if spec.Group != group || spec.Kind != kind {
+		return false
+	}
+	if len(spec.Versions) == 0 {
+		return true
+	}
+	for _, v := range spec.Versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
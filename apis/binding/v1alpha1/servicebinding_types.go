@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	"errors"
+
+	"github.com/redhat-developer/service-binding-operator/apis"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -69,6 +71,30 @@ type ServiceBindingSpec struct {
 	// +optional
 	// +kubebuilder:default:=true
 	BindAsFiles bool `json:"bindAsFiles"`
+
+	// RestartPolicy defines whether the bound application is restarted when
+	// the binding data change.  It can be set to `never` (default) or
+	// `onChange`, in which case a hash of the binding data is stamped into
+	// the pod template annotations of the application, causing its
+	// controller to roll out new pods.
+	// +optional
+	// +kubebuilder:validation:Enum=never;onChange
+	RestartPolicy apis.RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// FailurePolicy defines how failures to bind some of the applications are
+	// handled.  It can be set to `failFast` (default), in which case the first
+	// failure stops the binding of all applications, or `bestEffort`, in which
+	// case applications are bound independently and only the failed ones are
+	// retried.
+	// +optional
+	// +kubebuilder:validation:Enum=failFast;bestEffort
+	FailurePolicy apis.FailurePolicy `json:"failurePolicy,omitempty"`
+
+	// DryRun makes the binding report the changes it would apply to workloads in
+	// its status, without creating the binding secret nor modifying the workloads.
+	// It can be also requested by the `servicebinding.io/dry-run: "true"` annotation.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // ServiceBindingMapping defines a new binding from a set of existing bindings.
@@ -94,6 +120,20 @@ type ServiceBindingStatus struct {
 
 	// Secret indicates the name of the binding secret.
 	Secret string `json:"secret"`
+
+	// CrossNamespaceReferences lists secrets and config maps read from namespaces
+	// other than the namespace of the services referring to them.
+	// +optional
+	CrossNamespaceReferences []apis.CrossNamespaceReference `json:"crossNamespaceReferences,omitempty"`
+
+	// DryRun reports the changes the binding would apply to workloads when
+	// processed in dry-run mode.
+	// +optional
+	DryRun *apis.DryRunResult `json:"dryRun,omitempty"`
+
+	// Workloads reports the binding of each workload targeted by the binding.
+	// +optional
+	Workloads []apis.WorkloadStatus `json:"workloads,omitempty"`
 }
 
 // Ref identifies an object reference in the same namespace.
@@ -38,7 +38,8 @@ func (r *ServiceBinding) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.Validator = &ServiceBinding{}
 
 func checkNameAndSelector(r *ServiceBinding) error {
-	if r.Spec.Application.Name != "" && r.Spec.Application.LabelSelector != nil && r.Spec.Application.LabelSelector.MatchLabels != nil {
+	ls := r.Spec.Application.LabelSelector
+	if r.Spec.Application.Name != "" && ls != nil && (ls.MatchLabels != nil || ls.MatchExpressions != nil) {
 		err := errors.New("name and selector MUST NOT be defined in the application reference")
 		log.Error(err, "name and selector check failed")
 		return err
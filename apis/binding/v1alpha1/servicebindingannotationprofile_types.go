@@ -0,0 +1,74 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceBindingAnnotationProfileSpec defines annotations applied to CRDs of a given service kind
+type ServiceBindingAnnotationProfileSpec struct {
+	// Group of the service kind the profile applies to.
+	Group string `json:"group"`
+
+	// Kind of services the profile applies to.
+	Kind string `json:"kind"`
+
+	// Versions of the service kind the profile applies to.  It can be `*`
+	// for all versions, an exact version such as `v1-10-0`, a comma
+	// separated list of versions, or a range such as `>=v1beta1 <v2` or
+	// `>=1.8.0 <1.11.0`.  Kubernetes versions are ordered by their
+	// priority, i.e. `v1alpha1 < v1beta1 < v1`, other versions like
+	// `v1-10-0` are compared as `1.10.0`.
+	// +kubebuilder:default:="*"
+	// +optional
+	Versions string `json:"versions,omitempty"`
+
+	// Annotations applied to the CRD of matching services, e.g.
+	// `service.binding/username: path={.spec.user}`.
+	// +kubebuilder:validation:MinProperties:=1
+	Annotations map[string]string `json:"annotations"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.group`
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.kind`
+// +kubebuilder:printcolumn:name="Versions",type=string,JSONPath=`.spec.versions`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ServiceBindingAnnotationProfile declares binding annotations of a service
+// kind, applied to its CRD at runtime
+type ServiceBindingAnnotationProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceBindingAnnotationProfileSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceBindingAnnotationProfileList contains a list of ServiceBindingAnnotationProfile
+type ServiceBindingAnnotationProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceBindingAnnotationProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServiceBindingAnnotationProfile{}, &ServiceBindingAnnotationProfileList{})
+}
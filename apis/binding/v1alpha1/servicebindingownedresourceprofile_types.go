@@ -0,0 +1,74 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServiceBindingOwnedResourceProfileSpec defines binding data collected from resources of a given kind
+// owned by services
+type ServiceBindingOwnedResourceProfileSpec struct {
+	// Group of the owned resources, empty for the core group.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Version of the owned resources.
+	Version string `json:"version"`
+
+	// Kind of the owned resources.
+	Kind string `json:"kind"`
+
+	// Resource of the owned resources, i.e. the plural name of the kind,
+	// e.g. `ingresses`.
+	Resource string `json:"resource"`
+
+	// Annotations applied to owned resources to collect binding data, e.g.
+	// `service.binding/host: path={.spec.rules[0].host}`.
+	// +kubebuilder:validation:MinProperties:=1
+	Annotations map[string]string `json:"annotations"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Group",type=string,JSONPath=`.spec.group`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.spec.version`
+// +kubebuilder:printcolumn:name="Kind",type=string,JSONPath=`.spec.kind`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ServiceBindingOwnedResourceProfile declares binding annotations applied
+// to resources of a given kind owned by services, when binding resources
+// are detected
+type ServiceBindingOwnedResourceProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ServiceBindingOwnedResourceProfileSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ServiceBindingOwnedResourceProfileList contains a list of ServiceBindingOwnedResourceProfile
+type ServiceBindingOwnedResourceProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ServiceBindingOwnedResourceProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ServiceBindingOwnedResourceProfile{}, &ServiceBindingOwnedResourceProfileList{})
+}
@@ -1,8 +1,10 @@
 package apis
 
 import (
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"reflect"
 
 	"k8s.io/api/authentication/v1"
@@ -17,8 +19,128 @@ const (
 	finalizerName          = "finalizer.servicebinding.openshift.io"
 	requesterAnnotationKey = "servicebinding.io/requester"
 	MappingAnnotationKey   = "servicebinding.io/mapping"
+	DryRunAnnotationKey    = "servicebinding.io/dry-run"
+
+	bindingDataHashAnnotationPrefix = "data-hash.servicebinding.io/"
+	// maximum length of the name part of annotation keys
+	annotationNameMaxLength = 63
+)
+
+// RestartPolicy defines whether bound workloads are restarted when binding data change
+type RestartPolicy string
+
+const (
+	// Bound workloads are not restarted
+	RestartPolicyNever RestartPolicy = "never"
+	// Hash of binding data is stamped into pod template annotations of bound workloads,
+	// so that their controllers roll out new pods whenever binding data change
+	RestartPolicyOnChange RestartPolicy = "onChange"
 )
 
+// FailurePolicy defines how failures to bind some of the workloads of a binding are handled
+type FailurePolicy string
+
+const (
+	// The first failure stops the binding of all workloads
+	FailurePolicyFailFast FailurePolicy = "failFast"
+	// Workloads are bound independently, failures are aggregated and only failed workloads are retried
+	FailurePolicyBestEffort FailurePolicy = "bestEffort"
+)
+
+// CrossNamespaceReference identifies a Secret or ConfigMap read from a namespace
+// other than the namespace of the service referring to it
+type CrossNamespaceReference struct {
+	// Kind of the referent, either Secret or ConfigMap.
+	Kind string `json:"kind"`
+
+	// Namespace of the referent.
+	Namespace string `json:"namespace"`
+
+	// Name of the referent.
+	Name string `json:"name"`
+}
+
+// DryRunResult describes what a binding would project into workloads
+// +kubebuilder:object:generate=true
+type DryRunResult struct {
+	// Secret is the name of the binding secret the workloads would refer to.
+	// +optional
+	Secret string `json:"secret,omitempty"`
+
+	// BindingKeys lists the names of the binding data entries, their values are not exposed.
+	// +optional
+	BindingKeys []string `json:"bindingKeys,omitempty"`
+
+	// Workloads lists the patches that would be applied to bound workloads.
+	// +optional
+	Workloads []WorkloadPatch `json:"workloads,omitempty"`
+}
+
+// WorkloadPatch is a JSON patch that would be applied to a workload
+// +kubebuilder:object:generate=true
+type WorkloadPatch struct {
+	// Group of the workload.
+	Group string `json:"group"`
+
+	// Version of the workload.
+	Version string `json:"version"`
+
+	// Resource of the workload.
+	Resource string `json:"resource"`
+
+	// Name of the workload.
+	Name string `json:"name"`
+
+	// Patch is the JSON patch (RFC 6902) transforming the workload into the bound one.
+	Patch string `json:"patch"`
+}
+
+// WorkloadStatus reports the binding of a single workload
+// +kubebuilder:object:generate=true
+type WorkloadStatus struct {
+	// Group of the workload.
+	Group string `json:"group"`
+
+	// Version of the workload.
+	Version string `json:"version"`
+
+	// Resource of the workload.
+	Resource string `json:"resource"`
+
+	// Name of the workload.
+	Name string `json:"name"`
+
+	// ObservedGeneration is the generation of the workload binding data have been injected into.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Containers lists the names of the containers binding data have been injected into.
+	// +optional
+	Containers []string `json:"containers,omitempty"`
+
+	// MountPath is the path binding data are mounted at in the containers, when bound as files.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// Condition reports whether binding data have been injected into the workload.
+	Condition metav1.Condition `json:"condition"`
+}
+
+// Return true if the binding is annotated to be processed without modifying workloads
+func DryRunRequested(objMeta metav1.ObjectMeta) bool {
+	return objMeta.Annotations[DryRunAnnotationKey] == "true"
+}
+
+// Return the pod template annotation key holding hash of data of the given binding
+// names too long to fit the name part of the key are truncated and suffixed with a hash of the whole name
+func BindingDataHashAnnotationKey(bindingName string) string {
+	if len(bindingName) > annotationNameMaxLength {
+		suffix := fmt.Sprintf("-%x", sha256.Sum256([]byte(bindingName)))[:9]
+		bindingName = bindingName[:annotationNameMaxLength-len(suffix)] + suffix
+	}
+	return bindingDataHashAnnotationPrefix + bindingName
+}
+
 func MaybeAddFinalizer(obj Object) bool {
 	finalizers := obj.GetFinalizers()
 	for _, f := range finalizers {
@@ -35,8 +35,17 @@ const (
 	// DataCollectedReason indicates that bindings are collected successfully
 	DataCollectedReason = "DataCollected"
 
+	// UnboundReason is used in events about workloads binding data have been removed from
+	UnboundReason = "Unbound"
+
 	// RequiredBindingNotFound when some mandatory bindings are missing
 	RequiredBindingNotFound = "RequiredBindingNotFound"
+
+	// DryRunReason is used when the binding has been processed without modifying workloads
+	DryRunReason = "DryRun"
+
+	// WorkloadsNotBoundReason is used when some workloads of a binding processed on a best-effort basis are not bound
+	WorkloadsNotBoundReason = "WorkloadsNotBound"
 )
 
 type conditionsBuilder struct {
@@ -83,6 +92,12 @@ func (builder *conditionsBuilder) InjectionReady() *conditionsBuilder {
 	return builder
 }
 
+func (builder *conditionsBuilder) UnknownInjectionReady() *conditionsBuilder {
+	builder.status = v1.ConditionUnknown
+	builder.cndType = InjectionReady
+	return builder
+}
+
 func (builder *conditionsBuilder) NotBindingReady() *conditionsBuilder {
 	builder.status = v1.ConditionFalse
 	builder.cndType = BindingReady
@@ -92,7 +92,7 @@ func init() {
 
 var DefaultTemplate = ClusterWorkloadResourceMappingTemplate{
 	Version:     "*",
-	Annotations: ".spec.template.spec.annotations",
+	Annotations: ".spec.template.metadata.annotations",
 	Volumes:     ".spec.template.spec.volumes",
 	Containers: []ClusterWorkloadResourceMappingContainer{
 		{
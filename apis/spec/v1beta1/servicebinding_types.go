@@ -18,6 +18,8 @@ package v1beta1
 
 import (
 	"errors"
+
+	"github.com/redhat-developer/service-binding-operator/apis"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -79,6 +81,22 @@ type ServiceBindingSpec struct {
 	Service ServiceBindingServiceReference `json:"service"`
 	// Env is the collection of mappings from Secret entries to environment variables
 	Env []EnvMapping `json:"env,omitempty"`
+	// RestartPolicy defines whether the workload is restarted when the binding data change, either `never` (default)
+	// or `onChange`, in which case a hash of the binding data is stamped into the pod template annotations
+	// +kubebuilder:validation:Enum=never;onChange
+	RestartPolicy apis.RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// FailurePolicy defines how failures to bind some of the workloads are handled, either `failFast` (default),
+	// stopping the binding of all workloads on the first failure, or `bestEffort`, binding workloads independently
+	// and retrying only the failed ones
+	// +kubebuilder:validation:Enum=failFast;bestEffort
+	FailurePolicy apis.FailurePolicy `json:"failurePolicy,omitempty"`
+
+	// DryRun makes the binding report the changes it would apply to workloads in
+	// its status, without creating the binding secret nor modifying the workloads.
+	// It can be also requested by the `servicebinding.io/dry-run: "true"` annotation.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // These are valid conditions of ServiceBinding.
@@ -100,6 +118,20 @@ type ServiceBindingStatus struct {
 
 	// Binding exposes the projected secret for this ServiceBinding
 	Binding *ServiceBindingSecretReference `json:"binding,omitempty"`
+
+	// CrossNamespaceReferences lists secrets and config maps read from namespaces
+	// other than the namespace of the service referring to them.
+	// +optional
+	CrossNamespaceReferences []apis.CrossNamespaceReference `json:"crossNamespaceReferences,omitempty"`
+
+	// DryRun reports the changes the binding would apply to workloads when
+	// processed in dry-run mode.
+	// +optional
+	DryRun *apis.DryRunResult `json:"dryRun,omitempty"`
+
+	// Workloads reports the binding of each workload targeted by the binding.
+	// +optional
+	Workloads []apis.WorkloadStatus `json:"workloads,omitempty"`
 }
 
 // +kubebuilder:object:root=true
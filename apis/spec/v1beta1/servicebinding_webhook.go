@@ -44,7 +44,8 @@ func (r *ServiceBinding) SetupWebhookWithManager(mgr ctrl.Manager) error {
 var _ webhook.Validator = &ServiceBinding{}
 
 func checkNameAndSelector(r *ServiceBinding) error {
-	if r.Spec.Workload.Name != "" && r.Spec.Workload.Selector != nil && r.Spec.Workload.Selector.MatchLabels != nil {
+	ls := r.Spec.Workload.Selector
+	if r.Spec.Workload.Name != "" && ls != nil && (ls.MatchLabels != nil || ls.MatchExpressions != nil) {
 		err := errors.New("name and selector MUST NOT be defined in the application reference")
 		log.Error(err, "name and selector check failed")
 		return err
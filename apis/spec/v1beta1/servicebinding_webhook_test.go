@@ -103,4 +103,33 @@ var _ = Describe("Validation Webhook", func() {
 
 	})
 
+	It("should return error if both application name and selector with only match expressions are specified", func() {
+
+		ls := &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "env", Operator: metav1.LabelSelectorOpExists},
+			},
+		}
+
+		ref := ServiceBindingWorkloadReference{
+			APIVersion: "app/v1",
+			Kind:       "Foo",
+			Name:       "app1",
+			Selector:   ls,
+		}
+
+		sb := &ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sb1",
+				Namespace: "ns1",
+			},
+			Spec: ServiceBindingSpec{
+				Workload: ref,
+			},
+		}
+		_, err := sb.ValidateCreate()
+		Expect(err).To(HaveOccurred())
+
+	})
+
 })
@@ -0,0 +1,138 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// sbo binds workloads to services declared in local manifests, without a cluster, and prints
+// the service bindings, the binding secrets and the bound workloads
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/offline"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/context"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), `Usage: %s [flags] FILE...
+
+Runs service bindings found in the given manifests against an in-memory cluster holding all manifests,
+and prints the processed service bindings, their binding secrets and the bound workloads.
+Manifests are read from the standard input if FILE is "-".
+Exits with status 1 if any service binding is not bound.
+
+Flags:
+`, os.Args[0])
+	flag.PrintDefaults()
+}
+
+func main() {
+	var crossNamespaceAllowList string
+	flag.StringVar(&crossNamespaceAllowList, "cross-namespace-allow-list", "",
+		"Comma-separated list of namespaces services are allowed to read secrets and config maps from, '*' allowing any namespace.")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var objects []*unstructured.Unstructured
+	for _, file := range flag.Args() {
+		objs, err := decodeFile(file)
+		if err != nil {
+			fail(fmt.Errorf("cannot read %s: %w", file, err))
+		}
+		objects = append(objects, objs...)
+	}
+	cluster, err := offline.NewCluster(objects)
+	if err != nil {
+		fail(err)
+	}
+	results, err := cluster.Bind(context.WithCrossNamespaceAllowList(strings.Split(crossNamespaceAllowList, ",")))
+	if err != nil {
+		fail(err)
+	}
+	if len(results) == 0 {
+		fail(fmt.Errorf("no service binding found"))
+	}
+
+	bound := true
+	for _, r := range results {
+		if !r.Ready {
+			bound = false
+			fmt.Fprintf(os.Stderr, "service binding %s/%s is not bound: %v\n", r.Binding.GetNamespace(), r.Binding.GetName(), reason(r))
+		}
+		printObject(r.Binding)
+		if r.Secret != nil {
+			printObject(r.Secret)
+		}
+		for _, w := range r.Workloads {
+			printObject(w)
+		}
+	}
+	if !bound {
+		os.Exit(1)
+	}
+}
+
+func decodeFile(file string) ([]*unstructured.Unstructured, error) {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return offline.Decode(r)
+}
+
+// reason returns the pipeline error, or the message of the failed condition
+func reason(r *offline.Result) string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+	conditions, _, _ := unstructured.NestedSlice(r.Binding.Object, "status", "conditions")
+	var messages []string
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["status"] == "True" {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%v: %v %v", cond["type"], cond["reason"], cond["message"]))
+	}
+	return strings.Join(messages, ", ")
+}
+
+func printObject(obj *unstructured.Unstructured) {
+	out, err := yaml.Marshal(obj.Object)
+	if err != nil {
+		fail(err)
+	}
+	fmt.Printf("---\n%s", out)
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
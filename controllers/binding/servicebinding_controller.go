@@ -17,6 +17,8 @@ limitations under the License.
 package binding
 
 import (
+	"strings"
+
 	"github.com/go-logr/logr"
 	"github.com/redhat-developer/service-binding-operator/apis"
 	"github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
@@ -29,7 +31,9 @@ import (
 	"k8s.io/client-go/dynamic"
 	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 )
 
 // ServiceBindingReconciler reconciles a ServiceBinding object
@@ -40,14 +44,17 @@ type ServiceBindingReconciler struct {
 // +kubebuilder:rbac:groups=binding.operators.coreos.com,resources=servicebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=binding.operators.coreos.com,resources=servicebindings/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=binding.operators.coreos.com,resources=servicebindings/finalizers,verbs=update
+// +kubebuilder:rbac:groups=binding.operators.coreos.com,resources=bindingdefinitiontemplates,verbs=get;list;watch
 
-func New(client client.Client, log logr.Logger, scheme *runtime.Scheme) *ServiceBindingReconciler {
+func New(client client.Client, log logr.Logger, scheme *runtime.Scheme, resourceCache pipeline.ResourceCache, events <-chan event.GenericEvent) *ServiceBindingReconciler {
 	r := &ServiceBindingReconciler{
 		BindingReconciler: controllers.BindingReconciler{
-			Client: client,
-			Log:    log,
-			Scheme: scheme,
-			PipelineProvider: func(conf *rest.Config, lookup kubernetes.K8STypeLookup) (pipeline.Pipeline, error) {
+			Client:        client,
+			Log:           log,
+			Scheme:        scheme,
+			ResourceCache: resourceCache,
+			Events:        events,
+			PipelineProvider: func(conf *rest.Config, lookup kubernetes.K8STypeLookup, tracker pipeline.ResourceTracker, cache pipeline.ResourceCache, recorder record.EventRecorder) (pipeline.Pipeline, error) {
 				client, err := dynamic.NewForConfig(conf)
 				if err != nil {
 					return nil, err
@@ -56,7 +63,7 @@ func New(client client.Client, log logr.Logger, scheme *runtime.Scheme) *Service
 				if err != nil {
 					return nil, err
 				}
-				return builder.DefaultBuilder.WithContextProvider(context.Provider(client, authClient.SubjectAccessReviews(), lookup)).Build(), nil
+				return builder.DefaultBuilder.WithContextProvider(context.Provider(client, authClient.SubjectAccessReviews(), lookup, context.WithResourceTracker(tracker), context.WithResourceCache(cache), context.WithEventRecorder(recorder), context.WithCrossNamespaceAllowList(strings.Split(controllers.CrossNamespaceAllowList, ",")))).Build(), nil
 			},
 			ReconcilingObject: func() apis.Object { return &v1alpha1.ServiceBinding{} },
 		},
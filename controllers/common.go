@@ -3,37 +3,80 @@ package controllers
 import (
 	"context"
 	"flag"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes"
 
 	"github.com/go-logr/logr"
 	"github.com/redhat-developer/service-binding-operator/apis"
+	"github.com/redhat-developer/service-binding-operator/pkg/binding"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/cache"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/metrics"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/context/service"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/tracing"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/tracker"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list
-// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch
 // +kubebuilder:rbac:groups="operators.coreos.com",resources=clusterserviceversions,verbs=get;list
-// +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs=get;list;update;patch
-// +kubebuilder:rbac:groups=apps.openshift.io,resources=deploymentconfigs,verbs=get;list;update;patch
-// +kubebuilder:rbac:groups="",resources=pods;secrets;services;endpoints;configmaps,verbs=get;list
+// +kubebuilder:rbac:groups=apps,resources=deployments;daemonsets;replicasets;statefulsets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=apps.openshift.io,resources=deploymentconfigs,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=pods;secrets;services;endpoints;configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods;secrets,verbs=update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=create
-// +kubebuilder:rbac:groups=servicebinding.io,resources=clusterworkloadresourcemappings,verbs=get
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=servicebinding.io,resources=clusterworkloadresourcemappings,verbs=get;list;watch
 
 var (
 	MaxConcurrentReconciles int
+	CrossNamespaceAllowList string
 )
 
 func RegisterFlags(flags *flag.FlagSet) {
 	flags.IntVar(&MaxConcurrentReconciles, "max-concurrent-reconciles", 1, "max-concurrent-reconciles is the maximum number of concurrent Reconciles which can be run. Defaults to 1.")
+	flags.StringVar(&CrossNamespaceAllowList, "cross-namespace-allow-list", "", "cross-namespace-allow-list is the comma separated list of namespaces whose secrets and config maps can be referenced by services in other namespaces, '*' allowing any namespace. Defaults to none.")
+	flags.Func("bindable-owned-resources", "bindable-owned-resources is the comma separated list of resources owned by services that are looked up when detecting binding resources, e.g. 'secrets,routes.route.openshift.io'. Defaults to configmaps, secrets, services and routes.route.openshift.io.", func(value string) error {
+		return binding.OwnedKinds.Retain(strings.Split(value, ",")...)
+	})
+	flags.Func("max-ownership-depth", "max-ownership-depth is the maximum length of the ownership chains followed when detecting binding resources, values above 1 looking up resources owned by stateful sets and deployments owned by services. Defaults to 1, i.e. resources owned directly by services.", func(value string) error {
+		depth, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		if depth < 1 {
+			return fmt.Errorf("max-ownership-depth must be at least 1, got %d", depth)
+		}
+		service.MaxOwnershipDepth = depth
+		if depth > 1 {
+			for _, k := range binding.TraversedOwnedKinds {
+				binding.OwnedKinds.Register(k)
+			}
+		}
+		return nil
+	})
 }
 
 type BindingReconciler struct {
@@ -43,24 +86,66 @@ type BindingReconciler struct {
 
 	pipeline pipeline.Pipeline
 
-	PipelineProvider func(*rest.Config, kubernetes.K8STypeLookup) (pipeline.Pipeline, error)
+	tracker *tracker.Tracker
+
+	// ResourceCache serves reads of resources while processing bindings, shared by the binding controllers
+	ResourceCache pipeline.ResourceCache
+
+	// Events receives bindings to be reconciled again, e.g. when owned resource profiles change; nil if none
+	Events <-chan event.GenericEvent
+
+	PipelineProvider func(*rest.Config, kubernetes.K8STypeLookup, pipeline.ResourceTracker, pipeline.ResourceCache, record.EventRecorder) (pipeline.Pipeline, error)
 
 	ReconcilingObject func() apis.Object
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BindingReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	pipeline, err := r.PipelineProvider(mgr.GetConfig(), kubernetes.ResourceLookup(mgr.GetRESTMapper()))
+	authClient, err := authv1.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return err
+	}
+	r.tracker = tracker.New(r.Log.WithName("tracker"), mgr.GetCache(), mgr.GetRESTMapper(), authClient.SelfSubjectAccessReviews())
+	// requests sent while processing bindings are traced as children of the spans of pipeline handlers
+	config := rest.CopyConfig(mgr.GetConfig())
+	config.Wrap(tracing.WrapTransport)
+	pipeline, err := r.PipelineProvider(config, kubernetes.ResourceLookup(mgr.GetRESTMapper()), r.tracker, r.ResourceCache, mgr.GetEventRecorderFor("service-binding-operator"))
 	if err != nil {
 		return err
 	}
 	r.pipeline = pipeline
 	p := predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})
-	return ctrl.NewControllerManagedBy(mgr).
-		For(r.ReconcilingObject()).
-		WithEventFilter(p).
-		WithOptions(controller.Options{MaxConcurrentReconciles: MaxConcurrentReconciles}).
-		Complete(r)
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(r.ReconcilingObject(), builder.WithPredicates(p)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: MaxConcurrentReconciles})
+	if r.Events != nil {
+		b = b.WatchesRawSource(&source.Channel{Source: r.Events}, &handler.EnqueueRequestForObject{})
+	}
+	c, err := b.Build(r)
+	if err != nil {
+		return err
+	}
+	// services and resources referred by bindings are watched dynamically, as they get tracked
+	r.tracker.SetController(c)
+	return nil
+}
+
+// NewResourceCache creates the cache of resources read while processing bindings, to be shared by the binding
+// controllers of the given manager, and adds it to the manager
+func NewResourceCache(mgr ctrl.Manager) (*cache.Cache, error) {
+	client, err := dynamic.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	authClient, err := authv1.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	c := cache.New(ctrl.Log.WithName("cache"), client, authClient.SelfSubjectAccessReviews())
+	if err = mgr.Add(c); err != nil {
+		return nil, err
+	}
+	return c, nil
 }
 
 // +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
@@ -78,17 +163,29 @@ func (r *BindingReconciler) SetupWithManager(mgr ctrl.Manager) error {
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.7.0/pkg/reconcile
-func (r *BindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *BindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := r.Log.WithValues("serviceBinding", req.NamespacedName)
+	ctx, span := tracing.Tracer().Start(ctx, "Reconcile", trace.WithAttributes(
+		attribute.String("binding.namespace", req.Namespace),
+		attribute.String("binding.name", req.Name),
+	))
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
 	serviceBinding := r.ReconcilingObject()
 
-	err := r.Get(ctx, req.NamespacedName, serviceBinding)
+	err = r.Get(ctx, req.NamespacedName, serviceBinding)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
 			log.Info("ServiceBinding resource not found. Ignoring since object must be deleted", "name", req.NamespacedName, "err", err)
+			r.tracker.Track(req.NamespacedName, nil)
+			metrics.ForgetBinding(r.metricsKey(req))
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -105,7 +202,12 @@ func (r *BindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	} else {
 		log.Info("Deleted, unbind the application")
 	}
-	retry, delay, err := r.pipeline.Process(serviceBinding) // <.>
+	retry, delay, err := r.pipeline.Process(ctx, serviceBinding) // <.>
+	var ready metav1.ConditionStatus
+	if c := meta.FindStatusCondition(serviceBinding.StatusConditions(), apis.BindingReady); c != nil {
+		ready = c.Status
+	}
+	metrics.ObserveBinding(r.metricsKey(req), ready)
 	if !retry && err == nil {
 		if serviceBinding.HasDeletionTimestamp() {
 			if apis.MaybeRemoveFinalizer(serviceBinding) {
@@ -115,7 +217,7 @@ func (r *BindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 			}
 		}
 	}
-	result := ctrl.Result{Requeue: retry, RequeueAfter: delay}
+	result = ctrl.Result{Requeue: retry, RequeueAfter: delay}
 	log.Info("Done", "retry", retry, "error", err)
 	if retry {
 		return result, err
@@ -124,3 +226,8 @@ func (r *BindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 }
 
 // end::reconcile-func[]
+
+// metricsKey identifies the reconciled binding in metrics, bindings of different kinds may share the same name
+func (r *BindingReconciler) metricsKey(req ctrl.Request) metrics.BindingKey {
+	return metrics.BindingKey{Kind: fmt.Sprintf("%T", r.ReconcilingObject()), NamespacedName: req.NamespacedName}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	bindingapi "github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/binding/registry"
+	corev1 "k8s.io/api/core/v1"
+	v1apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// InvalidProfileReason is the reason of warning events recorded on profiles that cannot be applied
+const InvalidProfileReason = "InvalidProfile"
+
+// annotationProfileReconciler keeps the annotation registry in sync with ServiceBindingAnnotationProfile resources
+// and requests reconciliation of CRDs of the kinds the profiles apply to
+type annotationProfileReconciler struct {
+	client.Client
+	log                logr.Logger
+	recorder           record.EventRecorder
+	annotationRegistry registry.Registry
+
+	// CRDs sent to this channel are enqueued by the CRD controller
+	crdEvents chan<- event.GenericEvent
+}
+
+func (r *annotationProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("profile", req.Name)
+	previous, registered := r.annotationRegistry.GetProfile(req.Name)
+	var stale *registry.Profile
+	if registered {
+		stale = &previous
+	}
+	profile := &bindingapi.ServiceBindingAnnotationProfile{}
+	err := r.Get(ctx, req.NamespacedName, profile)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.annotationRegistry.RemoveProfile(req.Name)
+			log.Info("Profile removed")
+			return ctrl.Result{}, r.refreshCRDs(ctx, stale, nil)
+		}
+		return ctrl.Result{}, err
+	}
+	current := registry.Profile{
+		Group:       profile.Spec.Group,
+		Kind:        profile.Spec.Kind,
+		Versions:    profile.Spec.Versions,
+		Annotations: profile.Spec.Annotations,
+	}
+	err = r.annotationRegistry.SetProfile(req.Name, current)
+	if err != nil {
+		// retrying does not help until the profile is fixed, meanwhile the profile does not apply
+		r.annotationRegistry.RemoveProfile(req.Name)
+		log.Error(err, "Invalid profile")
+		r.recorder.Event(profile, corev1.EventTypeWarning, InvalidProfileReason, err.Error())
+		return ctrl.Result{}, r.refreshCRDs(ctx, stale, nil)
+	}
+	log.Info("Profile registered")
+	return ctrl.Result{}, r.refreshCRDs(ctx, stale, &current)
+}
+
+// refreshCRDs enqueues CRDs of the kinds of both profiles, so that the annotations in the registry get applied
+// and the annotations applied before but no longer in the registry get removed
+func (r *annotationProfileReconciler) refreshCRDs(ctx context.Context, previous *registry.Profile, current *registry.Profile) error {
+	if previous == nil && current == nil {
+		return nil
+	}
+	crds := &v1apiextensions.CustomResourceDefinitionList{}
+	if err := r.List(ctx, crds); err != nil {
+		return err
+	}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		matchesPrevious := previous != nil && crd.Spec.Group == previous.Group && crd.Spec.Names.Kind == previous.Kind
+		matchesCurrent := current != nil && crd.Spec.Group == current.Group && crd.Spec.Names.Kind == current.Kind
+		if !matchesPrevious && !matchesCurrent {
+			continue
+		}
+		select {
+		case r.crdEvents <- event.GenericEvent{Object: crd}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
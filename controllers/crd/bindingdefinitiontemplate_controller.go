@@ -0,0 +1,142 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	bindingapi "github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
+	v1apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// definitionTemplateReconciler requests reconciliation of CRDs and bindings of the kinds BindingDefinitionTemplate
+// resources apply to, so that bindable kinds and binding data follow template changes
+type definitionTemplateReconciler struct {
+	client.Client
+	log logr.Logger
+
+	// kinds the templates applied to when last reconciled, by template name,
+	// so that CRDs and bindings of the kind of removed templates or of their previous kind are refreshed too
+	lock  sync.Mutex
+	kinds map[string]schema.GroupKind
+
+	// CRDs sent to this channel are enqueued by the CRD controller
+	crdEvents chan<- event.GenericEvent
+
+	// bindings sent to this channel are enqueued by the binding controller, nil if bindings are not requeued
+	bindingEvents chan<- event.GenericEvent
+}
+
+func (r *definitionTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("template", req.Name)
+	template := &bindingapi.BindingDefinitionTemplate{}
+	err := r.Get(ctx, req.NamespacedName, template)
+	if err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, err
+	}
+	r.lock.Lock()
+	previous, registered := r.kinds[req.Name]
+	kinds := make(map[schema.GroupKind]bool)
+	if registered {
+		kinds[previous] = true
+	}
+	if err != nil {
+		delete(r.kinds, req.Name)
+		log.Info("Template removed")
+	} else {
+		current := schema.GroupKind{Group: template.Spec.Group, Kind: template.Spec.Kind}
+		r.kinds[req.Name] = current
+		kinds[current] = true
+		log.Info("Template changed")
+	}
+	r.lock.Unlock()
+	if err := r.refreshCRDs(ctx, kinds); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, r.refreshBindings(ctx, kinds)
+}
+
+// refreshCRDs enqueues CRDs of the given kinds, so that their bindability gets computed again
+func (r *definitionTemplateReconciler) refreshCRDs(ctx context.Context, kinds map[schema.GroupKind]bool) error {
+	if len(kinds) == 0 {
+		return nil
+	}
+	crds := &v1apiextensions.CustomResourceDefinitionList{}
+	if err := r.List(ctx, crds); err != nil {
+		return err
+	}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		if !kinds[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] {
+			continue
+		}
+		select {
+		case r.crdEvents <- event.GenericEvent{Object: crd}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// refreshBindings enqueues bindings having services of the given kinds, so that their binding data get collected
+// according to the templates; services referred by resource rather than kind are assumed to be of any kind
+func (r *definitionTemplateReconciler) refreshBindings(ctx context.Context, kinds map[schema.GroupKind]bool) error {
+	if r.bindingEvents == nil || len(kinds) == 0 {
+		return nil
+	}
+	bindings := &bindingapi.ServiceBindingList{}
+	if err := r.List(ctx, bindings); err != nil {
+		return err
+	}
+	for i := range bindings.Items {
+		sb := &bindings.Items[i]
+		if !hasServiceOfKind(sb, kinds) {
+			continue
+		}
+		select {
+		case r.bindingEvents <- event.GenericEvent{Object: sb}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func hasServiceOfKind(sb *bindingapi.ServiceBinding, kinds map[schema.GroupKind]bool) bool {
+	for _, s := range sb.Spec.Services {
+		if s.Kind == "" {
+			for gk := range kinds {
+				if gk.Group == s.Group {
+					return true
+				}
+			}
+			continue
+		}
+		if kinds[schema.GroupKind{Group: s.Group, Kind: s.Kind}] {
+			return true
+		}
+	}
+	return false
+}
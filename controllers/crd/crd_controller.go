@@ -19,11 +19,14 @@ package binding
 import (
 	"context"
 	"fmt"
-	"github.com/redhat-developer/service-binding-operator/pkg/binding/registry"
+	"strings"
 	"sync"
 
 	"github.com/go-logr/logr"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	bindingapi "github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
+	servicebinding "github.com/redhat-developer/service-binding-operator/pkg/binding"
+	"github.com/redhat-developer/service-binding-operator/pkg/binding/registry"
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes"
 	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/context/service"
@@ -33,9 +36,15 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/dynamic"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // CrdReconciler reconciles a CustomResourceDefinition resources
@@ -46,12 +55,21 @@ type CrdReconciler struct {
 	Scheme             *runtime.Scheme
 	bindableKinds      *sync.Map
 	annotationRegistry registry.Registry
+
+	// BindingEvents receives bindings detecting binding resources whenever owned resource profiles change,
+	// and bindings of services of the kinds binding definition templates apply to whenever the templates change;
+	// nil if they are not reconciled again
+	BindingEvents chan<- event.GenericEvent
 }
 
 // +kubebuilder:rbac:groups=binding.operators.coreos.com,resources=bindablekinds,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=binding.operators.coreos.com,resources=bindablekinds/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=binding.operators.coreos.com,resources=bindablekinds/finalizers,verbs=update
+// +kubebuilder:rbac:groups=binding.operators.coreos.com,resources=servicebindingannotationprofiles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=binding.operators.coreos.com,resources=servicebindingownedresourceprofiles,verbs=get;list;watch
+// +kubebuilder:rbac:groups=binding.operators.coreos.com,resources=bindingdefinitiontemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=operators.coreos.com,resources=clusterserviceversions,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -80,6 +98,14 @@ func (r *CrdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconc
 
 	toPersist := false
 
+	if crd.GetDeletionTimestamp().IsZero() && r.removeStaleAnnotations(crd) {
+		if err := r.Update(ctx, crd); err != nil {
+			log.Error(err, "Error updating CRD")
+			return ctrl.Result{}, err
+		}
+		log.Info("Stale annotations removed")
+	}
+
 	for i := range crd.Spec.Versions {
 		if !crd.Spec.Versions[i].Served {
 			continue
@@ -103,22 +129,29 @@ func (r *CrdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconc
 		if err != nil {
 			return ctrl.Result{}, err
 		}
-		if bindable {
+		annotations, found := r.annotationRegistry.GetAnnotations(gvk)
+		if found && annotationsOutdated(crd.GetAnnotations(), annotations, bindable) {
+			log.Info("Found bindable annotations", "gvk", gvk, "annotations", annotations)
+			crd.SetAnnotations(util.MergeMaps(crd.GetAnnotations(), annotations))
+			applied := appliedAnnotations(crd)
+			for k := range annotations {
+				applied.Insert(k)
+			}
+			setAppliedAnnotations(crd, applied)
+			err := r.Update(ctx, crd)
+			if err != nil {
+				log.Error(err, "Error updating CRD")
+				return ctrl.Result{}, err
+			}
+			log.Info("Annotations applied")
+		} else if bindable {
 			r.bindableKinds.Store(gvk, true)
 			toPersist = true
 			log.Info("bindable", "gvk", gvk)
-		} else {
-			annotations, found := r.annotationRegistry.GetAnnotations(gvk)
-			if found {
-				log.Info("Found bindable annotations", "gvk", gvk, "annotations", annotations)
-				crd.SetAnnotations(util.MergeMaps(crd.GetAnnotations(), annotations))
-				err := r.Update(ctx, crd)
-				if err != nil {
-					log.Error(err, "Error updating CRD")
-					return ctrl.Result{}, err
-				}
-				log.Info("Annotations applied")
-			}
+		} else if _, found := r.bindableKinds.LoadAndDelete(gvk); found {
+			// e.g. the template making the kind bindable has been removed
+			toPersist = true
+			log.Info("not bindable anymore", "gvk", gvk)
 		}
 	}
 
@@ -162,6 +195,76 @@ func (r *CrdReconciler) Reconcile(ctx context.Context, req ctrl.Request) (reconc
 	return ctrl.Result{}, nil
 }
 
+// annotationsOutdated returns true if registry annotations are missing on the CRD or differ from those applied before;
+// registry annotations are not applied to CRDs bindable on their own
+func annotationsOutdated(crdAnnotations map[string]string, annotations map[string]string, bindable bool) bool {
+	applied := false
+	outdated := false
+	for k, v := range annotations {
+		if current, ok := crdAnnotations[k]; ok {
+			applied = true
+			outdated = outdated || current != v
+		} else {
+			outdated = true
+		}
+	}
+	return outdated && (!bindable || applied)
+}
+
+// AppliedAnnotationsKey is the annotation listing the keys of the registry annotations applied to a CRD,
+// so that they get removed once the registry does not hold them anymore, e.g. when annotation profiles are deleted
+const AppliedAnnotationsKey = "servicebinding.io/applied-annotations"
+
+func appliedAnnotations(crd *v1apiextensions.CustomResourceDefinition) sets.Set[string] {
+	applied := sets.New[string]()
+	for _, k := range strings.Split(crd.GetAnnotations()[AppliedAnnotationsKey], ",") {
+		if k != "" {
+			applied.Insert(k)
+		}
+	}
+	return applied
+}
+
+func setAppliedAnnotations(crd *v1apiextensions.CustomResourceDefinition, applied sets.Set[string]) {
+	annotations := crd.GetAnnotations()
+	if applied.Len() == 0 {
+		delete(annotations, AppliedAnnotationsKey)
+	} else {
+		annotations[AppliedAnnotationsKey] = strings.Join(sets.List(applied), ",")
+	}
+	crd.SetAnnotations(annotations)
+}
+
+// removeStaleAnnotations removes annotations applied to the CRD that the registry does not hold anymore
+// for any served version of the CRD, returns true if any annotation has been removed
+func (r *CrdReconciler) removeStaleAnnotations(crd *v1apiextensions.CustomResourceDefinition) bool {
+	applied := appliedAnnotations(crd)
+	if applied.Len() == 0 {
+		return false
+	}
+	current := sets.New[string]()
+	for _, v := range crd.Spec.Versions {
+		if !v.Served {
+			continue
+		}
+		annotations, _ := r.annotationRegistry.GetAnnotations(schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind})
+		for k := range annotations {
+			current.Insert(k)
+		}
+	}
+	stale := applied.Difference(current)
+	if stale.Len() == 0 {
+		return false
+	}
+	annotations := crd.GetAnnotations()
+	for k := range stale {
+		delete(annotations, k)
+	}
+	crd.SetAnnotations(annotations)
+	setAppliedAnnotations(crd, applied.Intersection(current))
+	return true
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *CrdReconciler) SetupWithManager(mgr ctrl.Manager, bindableKinds *sync.Map) error {
 	r.bindableKinds = bindableKinds
@@ -171,7 +274,75 @@ func (r *CrdReconciler) SetupWithManager(mgr ctrl.Manager, bindableKinds *sync.M
 		return err
 	}
 	r.serviceBuilder = service.NewBuilder(kubernetes.ResourceLookup(mgr.GetRESTMapper())).WithClient(dynamicClient)
-	return ctrl.NewControllerManagedBy(mgr).
+	// CRDs of the kinds annotation profiles and binding definition templates apply to are reconciled
+	// whenever the profiles or templates change
+	crdEvents := make(chan event.GenericEvent)
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&v1apiextensions.CustomResourceDefinition{}).
-		Complete(r)
+		WatchesRawSource(&source.Channel{Source: crdEvents}, &handler.EnqueueRequestForObject{})
+	// CSV descriptors can make a CRD bindable, hence reconcile owned CRDs whenever a CSV changes;
+	// CSVs are available only on clusters running OLM
+	if _, err := mgr.GetRESTMapper().RESTMapping(csvGVK.GroupKind(), csvGVK.Version); err == nil {
+		csv := &unstructured.Unstructured{}
+		csv.SetGroupVersionKind(csvGVK)
+		b = b.Watches(csv, handler.EnqueueRequestsFromMapFunc(ownedCRDs))
+	}
+	if err := b.Complete(r); err != nil {
+		return err
+	}
+	err = ctrl.NewControllerManagedBy(mgr).
+		For(&bindingapi.ServiceBindingAnnotationProfile{}).
+		Complete(&annotationProfileReconciler{
+			Client:             r.Client,
+			log:                r.Log.WithName("ServiceBindingAnnotationProfile"),
+			recorder:           mgr.GetEventRecorderFor("service-binding-operator"),
+			annotationRegistry: r.annotationRegistry,
+			crdEvents:          crdEvents,
+		})
+	if err != nil {
+		return err
+	}
+	err = ctrl.NewControllerManagedBy(mgr).
+		For(&bindingapi.BindingDefinitionTemplate{}).
+		Complete(&definitionTemplateReconciler{
+			Client:        r.Client,
+			log:           r.Log.WithName("BindingDefinitionTemplate"),
+			kinds:         make(map[string]schema.GroupKind),
+			crdEvents:     crdEvents,
+			bindingEvents: r.BindingEvents,
+		})
+	if err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&bindingapi.ServiceBindingOwnedResourceProfile{}).
+		Complete(&ownedResourceProfileReconciler{
+			Client:        r.Client,
+			log:           r.Log.WithName("ServiceBindingOwnedResourceProfile"),
+			recorder:      mgr.GetEventRecorderFor("service-binding-operator"),
+			ownedKinds:    servicebinding.OwnedKinds,
+			bindingEvents: r.BindingEvents,
+		})
+}
+
+var csvGVK = olmv1alpha1.SchemeGroupVersion.WithKind("ClusterServiceVersion")
+
+// ownedCRDs returns reconcile requests for CRDs owned by the given CSV
+func ownedCRDs(_ context.Context, obj client.Object) []reconcile.Request {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	owned, _, _ := unstructured.NestedSlice(u.Object, "spec", "customresourcedefinitions", "owned")
+	var requests []reconcile.Request
+	for _, o := range owned {
+		data, ok := o.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := data["name"].(string); ok && name != "" {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+		}
+	}
+	return requests
 }
@@ -0,0 +1,100 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	bindingapi "github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
+	servicebinding "github.com/redhat-developer/service-binding-operator/pkg/binding"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// ownedResourceProfileReconciler keeps the owned kind registry in sync with ServiceBindingOwnedResourceProfile resources
+// and requests reconciliation of bindings detecting binding resources
+type ownedResourceProfileReconciler struct {
+	client.Client
+	log        logr.Logger
+	recorder   record.EventRecorder
+	ownedKinds *servicebinding.OwnedKindRegistry
+
+	// bindings sent to this channel are enqueued by the binding controller, nil if bindings are not requeued
+	bindingEvents chan<- event.GenericEvent
+}
+
+func (r *ownedResourceProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("profile", req.Name)
+	profile := &bindingapi.ServiceBindingOwnedResourceProfile{}
+	err := r.Get(ctx, req.NamespacedName, profile)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.ownedKinds.RemoveProfile(req.Name)
+			log.Info("Profile removed")
+			return ctrl.Result{}, r.refreshBindings(ctx)
+		}
+		return ctrl.Result{}, err
+	}
+	err = r.ownedKinds.SetProfile(req.Name, servicebinding.OwnedKind{
+		GroupVersionResource: schema.GroupVersionResource{
+			Group:    profile.Spec.Group,
+			Version:  profile.Spec.Version,
+			Resource: profile.Spec.Resource,
+		},
+		Kind:        profile.Spec.Kind,
+		Definitions: profile.Spec.Annotations,
+	})
+	if err != nil {
+		// retrying does not help until the profile is fixed, meanwhile the profile does not apply
+		r.ownedKinds.RemoveProfile(req.Name)
+		log.Error(err, "Invalid profile")
+		r.recorder.Event(profile, corev1.EventTypeWarning, InvalidProfileReason, err.Error())
+		return ctrl.Result{}, r.refreshBindings(ctx)
+	}
+	log.Info("Profile registered")
+	return ctrl.Result{}, r.refreshBindings(ctx)
+}
+
+// refreshBindings enqueues bindings detecting binding resources, so that binding data of owned resources
+// get collected according to the profiles in the registry
+func (r *ownedResourceProfileReconciler) refreshBindings(ctx context.Context) error {
+	if r.bindingEvents == nil {
+		return nil
+	}
+	bindings := &bindingapi.ServiceBindingList{}
+	if err := r.List(ctx, bindings); err != nil {
+		return err
+	}
+	for i := range bindings.Items {
+		sb := &bindings.Items[i]
+		if !sb.Spec.DetectBindingResources {
+			continue
+		}
+		select {
+		case r.bindingEvents <- event.GenericEvent{Object: sb}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package spec
 
 import (
+	"strings"
+
 	"github.com/go-logr/logr"
 	"github.com/redhat-developer/service-binding-operator/apis"
 	specv1beta1 "github.com/redhat-developer/service-binding-operator/apis/spec/v1beta1"
@@ -29,6 +31,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -41,13 +44,14 @@ type ServiceBindingReconciler struct {
 // +kubebuilder:rbac:groups=servicebinding.io,resources=servicebindings/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=servicebinding.io,resources=servicebindings/finalizers,verbs=update
 
-func New(client client.Client, log logr.Logger, scheme *runtime.Scheme) *ServiceBindingReconciler {
+func New(client client.Client, log logr.Logger, scheme *runtime.Scheme, resourceCache pipeline.ResourceCache) *ServiceBindingReconciler {
 	r := &ServiceBindingReconciler{
 		BindingReconciler: controllers.BindingReconciler{
-			Client: client,
-			Log:    log,
-			Scheme: scheme,
-			PipelineProvider: func(conf *rest.Config, lookup kubernetes.K8STypeLookup) (pipeline.Pipeline, error) {
+			Client:        client,
+			Log:           log,
+			Scheme:        scheme,
+			ResourceCache: resourceCache,
+			PipelineProvider: func(conf *rest.Config, lookup kubernetes.K8STypeLookup, tracker pipeline.ResourceTracker, cache pipeline.ResourceCache, recorder record.EventRecorder) (pipeline.Pipeline, error) {
 				client, err := dynamic.NewForConfig(conf)
 				if err != nil {
 					return nil, err
@@ -56,7 +60,7 @@ func New(client client.Client, log logr.Logger, scheme *runtime.Scheme) *Service
 				if err != nil {
 					return nil, err
 				}
-				return builder.SpecBuilder.WithContextProvider(context.SpecProvider(client, authClient.SubjectAccessReviews(), lookup)).Build(), nil
+				return builder.SpecBuilder.WithContextProvider(context.SpecProvider(client, authClient.SubjectAccessReviews(), lookup, context.WithResourceTracker(tracker), context.WithResourceCache(cache), context.WithEventRecorder(recorder), context.WithCrossNamespaceAllowList(strings.Split(controllers.CrossNamespaceAllowList, ",")))).Build(), nil
 			},
 			ReconcilingObject: func() apis.Object { return &specv1beta1.ServiceBinding{} },
 		},
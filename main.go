@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -30,7 +31,9 @@ import (
 
 	crdcontrollers "github.com/redhat-developer/service-binding-operator/controllers/crd"
 
+	servicebinding "github.com/redhat-developer/service-binding-operator/pkg/binding"
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/tracing"
 
 	"github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/controllers"
@@ -45,6 +48,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -93,12 +97,15 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var enableHTTP2 bool
+	var tracingExporter string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false, "If HTTP/2 should be enabled for the metrics and webhook servers.")
+	flag.StringVar(&tracingExporter, "tracing-exporter", tracing.NoExporter, "The exporter of traces about binding processing, either 'otlp' to send them to the OpenTelemetry collector "+
+		"at the endpoint given by the OTEL_EXPORTER_OTLP_ENDPOINT environment variable, or 'stdout'. Tracing is disabled by default.")
 	controllers.RegisterFlags(flag.CommandLine)
 
 	opts := zap.Options{
@@ -115,6 +122,12 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracingExporter)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	webhookOptions := webhook.Options{
 		Port: 9443,
 	}
@@ -158,10 +171,22 @@ func main() {
 	}
 	setupLog.Info("Service account", "name", serviceAccountName)
 
+	servicebinding.DefinitionSources.Register(servicebinding.TemplateSourcePrecedence, servicebinding.NewTemplateSource(mgr.GetClient()))
+
+	resourceCache, err := controllers.NewResourceCache(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to create resource cache")
+		os.Exit(1)
+	}
+
+	// bindings are reconciled again when owned resource profiles or binding definition templates affecting them change
+	bindingEvents := make(chan event.GenericEvent)
 	if err = binding.New(
 		mgr.GetClient(),
 		ctrl.Log.WithName("controllers").WithName("ServiceBinding"),
 		mgr.GetScheme(),
+		resourceCache,
+		bindingEvents,
 	).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ServiceBinding")
 		os.Exit(1)
@@ -174,6 +199,7 @@ func main() {
 		mgr.GetClient(),
 		ctrl.Log.WithName("controllers").WithName("SPEC ServiceBinding"),
 		mgr.GetScheme(),
+		resourceCache,
 	).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SPEC ServiceBinding")
 		os.Exit(1)
@@ -199,9 +225,10 @@ func main() {
 
 	bindableKinds := &sync.Map{}
 	if err = (&crdcontrollers.CrdReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("CRD v1"),
-		Scheme: mgr.GetScheme(),
+		Client:        mgr.GetClient(),
+		Log:           ctrl.Log.WithName("controllers").WithName("CRD v1"),
+		Scheme:        mgr.GetScheme(),
+		BindingEvents: bindingEvents,
 	}).SetupWithManager(mgr, bindableKinds); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CRD v1")
 		os.Exit(1)
@@ -218,7 +245,11 @@ func main() {
 	}
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	if serr := shutdownTracing(context.Background()); serr != nil {
+		setupLog.Error(serr, "unable to flush traces")
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
@@ -20,6 +20,11 @@ GO_BUILD_FLAGS ?= -ldflags="-s -w" -trimpath
 build:
 	$(GO) build $(GO_BUILD_FLAGS) -o bin/manager main.go
 
+.PHONY: build-cli
+## Build sbo CLI binding local manifests without a cluster
+build-cli:
+	$(GO) build $(GO_BUILD_FLAGS) -o bin/sbo ./cmd/sbo
+
 .PHONY: manifests
 ## Generate manifests e.g. CRD, RBAC etc.
 manifests: controller-gen
@@ -26,11 +26,15 @@ type modelKey string
 
 const (
 	pathModelKey                    modelKey = "path"
+	exprModelKey                    modelKey = "expr"
+	transformModelKey               modelKey = "transform"
 	optionalKey                     modelKey = "optional"
 	objectTypeModelKey              modelKey = "objectType"
 	sourceKeyModelKey               modelKey = "sourceKey"
 	sourceValueModelKey             modelKey = "sourceValue"
 	elementTypeModelKey             modelKey = "elementType"
+	namespaceModelKey               modelKey = "namespace"
+	namespacePathModelKey           modelKey = "namespacePath"
 	AnnotationPrefix                         = "service.binding"
 	ProvisionedServiceAnnotationKey          = "servicebinding.io/provisioned-service"
 	TypeKey                                  = AnnotationPrefix + "/type"
@@ -94,6 +98,18 @@ func (m *annotationBackedDefinitionBuilder) Build() (Definition, error) {
 		return nil, fmt.Errorf("could not create binding model for annotation key %s and value %s: %w", m.name, m.value, err)
 	}
 
+	d, err := m.build(mod, outputName)
+	if err != nil || len(mod.transforms) == 0 {
+		return d, err
+	}
+	return &transformedDefinition{
+		Definition: d,
+		outputName: outputName,
+		transforms: mod.transforms,
+	}, nil
+}
+
+func (m *annotationBackedDefinitionBuilder) build(mod *model, outputName string) (Definition, error) {
 	switch {
 	case (mod.isStringElementType() && mod.isStringObjectType()) || mod.value != "":
 		return &stringDefinition{
@@ -101,6 +117,7 @@ func (m *annotationBackedDefinitionBuilder) Build() (Definition, error) {
 			value:      mod.value,
 			definition: definition{
 				path:     mod.path,
+				expr:     mod.expr,
 				optional: mod.optional,
 			},
 		}, nil
@@ -112,8 +129,10 @@ func (m *annotationBackedDefinitionBuilder) Build() (Definition, error) {
 			outputName:            outputName,
 			definition: definition{
 				path:     mod.path,
+				expr:     mod.expr,
 				optional: mod.optional,
 			},
+			namespace: mod.namespace,
 			sourceKey: mod.sourceKey,
 		}, nil
 
@@ -124,8 +143,10 @@ func (m *annotationBackedDefinitionBuilder) Build() (Definition, error) {
 			outputName:            outputName,
 			definition: definition{
 				path:     mod.path,
+				expr:     mod.expr,
 				optional: mod.optional,
 			},
+			namespace:   mod.namespace,
 			sourceValue: mod.sourceValue,
 		}, nil
 
@@ -134,6 +155,7 @@ func (m *annotationBackedDefinitionBuilder) Build() (Definition, error) {
 			outputName: outputName,
 			definition: definition{
 				path:     mod.path,
+				expr:     mod.expr,
 				optional: mod.optional,
 			},
 		}, nil
@@ -143,6 +165,7 @@ func (m *annotationBackedDefinitionBuilder) Build() (Definition, error) {
 			outputName: outputName,
 			definition: definition{
 				path:     mod.path,
+				expr:     mod.expr,
 				optional: mod.optional,
 			},
 			sourceKey:   mod.sourceKey,
@@ -154,6 +177,7 @@ func (m *annotationBackedDefinitionBuilder) Build() (Definition, error) {
 			outputName: outputName,
 			definition: definition{
 				path:     mod.path,
+				expr:     mod.expr,
 				optional: mod.optional,
 			},
 			sourceValue: mod.sourceValue,
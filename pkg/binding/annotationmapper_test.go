@@ -54,6 +54,34 @@ func TestAnnotationBackedBuilderInvalidAnnotation(t *testing.T) {
 				value: "path={.status.dbCredential},objectType=asdf,valueKey=username",
 			},
 		},
+		{
+			description: "namespace without secret or config map object type",
+			builder: &annotationBackedDefinitionBuilder{
+				name:  "service.binding/username",
+				value: "path={.status.username},namespace=credentials",
+			},
+		},
+		{
+			description: "both namespace and namespacePath",
+			builder: &annotationBackedDefinitionBuilder{
+				name:  "service.binding",
+				value: "path={.status.secret},objectType=Secret,namespace=credentials,namespacePath={.status.namespace}",
+			},
+		},
+		{
+			description: "empty namespace",
+			builder: &annotationBackedDefinitionBuilder{
+				name:  "service.binding",
+				value: "path={.status.secret},objectType=Secret,namespace=",
+			},
+		},
+		{
+			description: "invalid namespacePath",
+			builder: &annotationBackedDefinitionBuilder{
+				name:  "service.binding",
+				value: "path={.status.secret},objectType=Secret,namespacePath=.status.namespace",
+			},
+		},
 	}
 
 	for _, tc := range testCases {
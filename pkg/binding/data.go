@@ -0,0 +1,69 @@
+package binding
+
+import (
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// SecretEntries returns entries of the given Secret merged from .data and .stringData, and false if none of these
+// fields exists. Values are returned as strings, except values which are not valid UTF-8, which are returned as []byte
+// so that they can be projected byte-for-byte.
+func SecretEntries(u *unstructured.Unstructured) (map[string]interface{}, bool, error) {
+	result, found, err := decodedEntries(u, "data", textOrBytes)
+	if err != nil {
+		return nil, false, err
+	}
+	// stringData is write-only on the API server, but resources might not have been persisted yet
+	stringData, stringDataFound, err := unstructured.NestedStringMap(u.Object, "stringData")
+	if err != nil {
+		return nil, false, err
+	}
+	for k, v := range stringData {
+		result[k] = v
+	}
+	return result, found || stringDataFound, nil
+}
+
+// ConfigMapEntries returns entries of the given ConfigMap merged from .data and .binaryData, and false if none of
+// these fields exists. Entries of .data are returned as strings, while entries of .binaryData are returned as []byte
+// so that they can be projected byte-for-byte.
+func ConfigMapEntries(u *unstructured.Unstructured) (map[string]interface{}, bool, error) {
+	data, found, err := unstructured.NestedStringMap(u.Object, "data")
+	if err != nil {
+		return nil, false, err
+	}
+	result, binaryDataFound, err := decodedEntries(u, "binaryData", func(b []byte) interface{} { return b })
+	if err != nil {
+		return nil, false, err
+	}
+	for k, v := range data {
+		result[k] = v
+	}
+	return result, found || binaryDataFound, nil
+}
+
+func decodedEntries(u *unstructured.Unstructured, field string, convert func([]byte) interface{}) (map[string]interface{}, bool, error) {
+	data, found, err := unstructured.NestedStringMap(u.Object, field)
+	if err != nil {
+		return nil, false, err
+	}
+	result := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		b, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot decode value of %s: %w", k, err)
+		}
+		result[k] = convert(b)
+	}
+	return result, found, nil
+}
+
+func textOrBytes(b []byte) interface{} {
+	if utf8.Valid(b) {
+		return string(b)
+	}
+	return b
+}
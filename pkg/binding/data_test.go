@@ -0,0 +1,57 @@
+package binding
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestSecretEntries(t *testing.T) {
+	binary := []byte{0xde, 0xad, 0xbe, 0xef}
+	entries, found, err := SecretEntries(&unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{
+			"username": base64.StdEncoding.EncodeToString([]byte("admin")),
+			"keystore": base64.StdEncoding.EncodeToString(binary),
+		},
+		"stringData": map[string]interface{}{
+			"password": "secret",
+		},
+	}})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, map[string]interface{}{"username": "admin", "keystore": binary, "password": "secret"}, entries)
+
+	_, found, err = SecretEntries(&unstructured.Unstructured{Object: map[string]interface{}{}})
+	require.NoError(t, err)
+	require.False(t, found)
+
+	_, _, err = SecretEntries(&unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{"username": "not base64!"},
+	}})
+	require.Error(t, err)
+}
+
+func TestConfigMapEntries(t *testing.T) {
+	text := []byte("plain text")
+	entries, found, err := ConfigMapEntries(&unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{
+			"host": "example.com",
+		},
+		"binaryData": map[string]interface{}{
+			"truststore": base64.StdEncoding.EncodeToString(text),
+		},
+	}})
+	require.NoError(t, err)
+	require.True(t, found)
+	// binaryData entries are kept as bytes even when valid UTF-8
+	require.Equal(t, map[string]interface{}{"host": "example.com", "truststore": text}, entries)
+
+	entries, found, err = ConfigMapEntries(&unstructured.Unstructured{Object: map[string]interface{}{
+		"binaryData": map[string]interface{}{},
+	}})
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Empty(t, entries)
+}
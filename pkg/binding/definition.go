@@ -1,9 +1,9 @@
 package binding
 
 import (
-	"encoding/base64"
 	"errors"
 	"fmt"
+	"reflect"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -49,13 +49,26 @@ type DefinitionBuilder interface {
 
 type definition struct {
 	path     string
+	expr     *expression
 	optional bool
 }
 
+// GetPath returns the JSONPath, or the expression if the definition is expression based
 func (d *definition) GetPath() string {
+	if d.expr != nil {
+		return d.expr.source
+	}
 	return d.path
 }
 
+// values returns values found at the path, or the result of the expression
+func (d *definition) values(u *unstructured.Unstructured) ([]reflect.Value, error) {
+	if d.expr != nil {
+		return d.expr.eval(u.Object)
+	}
+	return getValuesByJSONPath(u.Object, d.path)
+}
+
 type stringDefinition struct {
 	outputName string
 	value      string
@@ -82,7 +95,7 @@ func (d *stringDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
 			},
 		}, nil
 	}
-	val, err := getValuesByJSONPath(u.Object, d.path)
+	val, err := d.values(u)
 	if err != nil {
 		if d.optional {
 			return &value{}, nil
@@ -91,7 +104,7 @@ func (d *stringDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
 	}
 
 	if len(val) != 1 {
-		return nil, fmt.Errorf("only one value should be returned for %v but we got %v", d.path, val)
+		return nil, fmt.Errorf("only one value should be returned for %v but we got %v", d.GetPath(), val)
 	}
 
 	m := map[string]interface{}{
@@ -101,10 +114,55 @@ func (d *stringDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
 	return &value{v: m}, nil
 }
 
+// dataNamespace locates the namespace of Secrets and ConfigMaps referenced by services
+type dataNamespace struct {
+	// namespace name
+	name string
+	// JSONPath template resolving to the namespace name
+	path string
+}
+
+// resolve returns the namespace of the referenced resource, the namespace of the service if not set
+func (n dataNamespace) resolve(u *unstructured.Unstructured) (string, error) {
+	if n.name != "" {
+		return n.name, nil
+	}
+	if n.path == "" {
+		return u.GetNamespace(), nil
+	}
+	res, err := getValuesByJSONPath(u.Object, n.path)
+	if err != nil {
+		return "", err
+	}
+	if len(res) != 1 {
+		return "", fmt.Errorf("only one value should be returned for %v but we got %v", n.path, res)
+	}
+	namespace := fmt.Sprintf("%v", res[0].Interface())
+	if namespace == "" {
+		return "", fmt.Errorf("empty namespace returned for %v", n.path)
+	}
+	return namespace, nil
+}
+
+func (r *secretConfigMapReader) read(objectType objectType, namespace string, name string) (*unstructured.Unstructured, error) {
+	if objectType == secretObjectType {
+		return r.secretReader(namespace, name)
+	}
+	return r.configMapReader(namespace, name)
+}
+
+func (r *secretConfigMapReader) entries(objectType objectType, u *unstructured.Unstructured) (map[string]interface{}, bool, error) {
+	if objectType == secretObjectType {
+		return SecretEntries(u)
+	}
+	return ConfigMapEntries(u)
+}
+
 type stringFromDataFieldDefinition struct {
 	secretConfigMapReader *secretConfigMapReader
 	objectType            objectType
 	outputName            string
+	namespace             dataNamespace
 	definition
 	sourceKey string
 }
@@ -124,7 +182,7 @@ func (d *stringFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Val
 		return nil, errors.New("kubeClient required for this functionality")
 	}
 
-	res, err := getValuesByJSONPath(u.Object, d.path)
+	res, err := d.values(u)
 	if err != nil {
 		if d.optional {
 			return &value{}, nil
@@ -133,35 +191,27 @@ func (d *stringFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Val
 	}
 
 	if len(res) != 1 {
-		return nil, fmt.Errorf("only one value should be returned for %v but we got %v", d.path, res)
+		return nil, fmt.Errorf("only one value should be returned for %v but we got %v", d.GetPath(), res)
 	}
 	resourceName := res[0].String()
 
-	var otherObj *unstructured.Unstructured
-	if d.objectType == secretObjectType {
-		otherObj, err = d.secretConfigMapReader.secretReader(u.GetNamespace(), resourceName)
-	} else if d.objectType == configMapObjectType {
-		otherObj, err = d.secretConfigMapReader.configMapReader(u.GetNamespace(), resourceName)
+	namespace, err := d.namespace.resolve(u)
+	if err != nil {
+		return nil, err
 	}
-
+	otherObj, err := d.secretConfigMapReader.read(d.objectType, namespace, resourceName)
 	if err != nil {
 		return nil, err
 	}
 
-	val, ok, err := unstructured.NestedString(otherObj.Object, "data", d.sourceKey)
+	entries, _, err := d.secretConfigMapReader.entries(d.objectType, otherObj)
 	if err != nil {
 		return nil, err
 	}
+	val, ok := entries[d.sourceKey]
 	if !ok {
 		return nil, errors.New("not found")
 	}
-	if d.objectType == secretObjectType {
-		n, err := base64.StdEncoding.DecodeString(val)
-		if err != nil {
-			return nil, err
-		}
-		val = string(n)
-	}
 	v := map[string]interface{}{
 		"": val,
 	}
@@ -172,6 +222,7 @@ type mapFromDataFieldDefinition struct {
 	secretConfigMapReader *secretConfigMapReader
 	objectType            objectType
 	outputName            string
+	namespace             dataNamespace
 	sourceValue           string
 	definition
 }
@@ -190,7 +241,7 @@ func (d *mapFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Value,
 		return nil, errors.New("kubeClient required for this functionality")
 	}
 
-	res, err := getValuesByJSONPath(u.Object, d.path)
+	res, err := d.values(u)
 	if err != nil {
 		if d.optional {
 			return &value{}, nil
@@ -198,22 +249,20 @@ func (d *mapFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Value,
 		return nil, err
 	}
 	if len(res) != 1 {
-		return nil, fmt.Errorf("only one value should be returned for %v but we got %v", d.path, res)
+		return nil, fmt.Errorf("only one value should be returned for %v but we got %v", d.GetPath(), res)
 	}
 	resourceName := fmt.Sprintf("%v", res[0].Interface())
 
-	var otherObj *unstructured.Unstructured
-	if d.objectType == secretObjectType {
-		otherObj, err = d.secretConfigMapReader.secretReader(u.GetNamespace(), resourceName)
-	} else if d.objectType == configMapObjectType {
-		otherObj, err = d.secretConfigMapReader.configMapReader(u.GetNamespace(), resourceName)
+	namespace, err := d.namespace.resolve(u)
+	if err != nil {
+		return nil, err
 	}
-
+	otherObj, err := d.secretConfigMapReader.read(d.objectType, namespace, resourceName)
 	if err != nil {
 		return nil, err
 	}
 
-	val, ok, err := unstructured.NestedStringMap(otherObj.Object, "data")
+	val, ok, err := d.secretConfigMapReader.entries(d.objectType, otherObj)
 	if err != nil {
 		return nil, err
 	}
@@ -221,26 +270,16 @@ func (d *mapFromDataFieldDefinition) Apply(u *unstructured.Unstructured) (Value,
 		return nil, errors.New("not found")
 	}
 
-	outputVal := make(map[string]string)
+	outputVal := make(map[string]interface{})
 
 	for k, v := range val {
 		if len(d.sourceValue) > 0 && k != d.sourceValue {
 			continue
 		}
-		var n string
-		if d.objectType == secretObjectType {
-			b, err := base64.StdEncoding.DecodeString(v)
-			if err != nil {
-				return nil, err
-			}
-			n = string(b)
-		} else {
-			n = v
-		}
 		if len(d.sourceValue) > 0 && len(d.outputName) > 0 {
-			outputVal[d.outputName] = string(n)
+			outputVal[d.outputName] = v
 		} else {
-			outputVal[k] = string(n)
+			outputVal[k] = v
 		}
 	}
 
@@ -262,7 +301,7 @@ func (d *stringOfMapDefinition) NonExistingOptional(v Value) bool {
 }
 
 func (d *stringOfMapDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
-	val, err := getValuesByJSONPath(u.Object, d.path)
+	val, err := d.values(u)
 	if err != nil {
 		if d.optional {
 			return &value{}, nil
@@ -270,12 +309,12 @@ func (d *stringOfMapDefinition) Apply(u *unstructured.Unstructured) (Value, erro
 		return nil, err
 	}
 	if len(val) != 1 {
-		return nil, fmt.Errorf("only one value should be returned for %v but we got %v", d.path, val)
+		return nil, fmt.Errorf("only one value should be returned for %v but we got %v", d.GetPath(), val)
 	}
 
 	valMap, ok := val[0].Interface().(map[string]interface{})
 	if !ok {
-		return nil, fmt.Errorf("returned value for %v should be map, but we got %v", d.path, val[0].Interface())
+		return nil, fmt.Errorf("returned value for %v should be map, but we got %v", d.GetPath(), val[0].Interface())
 	}
 
 	outputName := d.outputName
@@ -305,7 +344,7 @@ func (d *sliceOfMapsFromPathDefinition) NonExistingOptional(v Value) bool {
 }
 
 func (d *sliceOfMapsFromPathDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
-	val, err := getValuesByJSONPath(u.Object, d.path)
+	val, err := d.values(u)
 	if err != nil {
 		if d.optional {
 			return &value{}, nil
@@ -358,7 +397,7 @@ func (d *sliceOfStringsFromPathDefinition) NonExistingOptional(v Value) bool {
 }
 
 func (d *sliceOfStringsFromPathDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
-	val, err := getValuesByJSONPath(u.Object, d.path)
+	val, err := d.values(u)
 	if err != nil {
 		if d.optional {
 			return &value{}, nil
@@ -262,7 +262,7 @@ func TestMapFromSecretDataField(t *testing.T) {
 		},
 	})
 	require.NoError(t, err)
-	v := map[string]string{
+	v := map[string]interface{}{
 		"username": "user",
 		"password": "password",
 	}
@@ -293,13 +293,62 @@ func TestMapFromConstructedSecretName(t *testing.T) {
 		},
 	})
 	require.NoError(t, err)
-	v := map[string]string{
+	v := map[string]interface{}{
 		"username": "user",
 		"password": "password",
 	}
 	require.Equal(t, v, val.Get())
 }
 
+func TestMapFromSecretInOtherNamespace(t *testing.T) {
+	f := mocks.NewFake(t, "credentials")
+	f.AddMockedUnstructuredSecret("dbCredentials-secret")
+	u := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"namespace": "test-namespace",
+			},
+			"status": map[string]interface{}{
+				"dbCredentials":   "dbCredentials-secret",
+				"secretNamespace": "credentials",
+			},
+		},
+	}
+	expected := map[string]interface{}{
+		"username": "user",
+		"password": "password",
+	}
+	for _, ns := range []dataNamespace{{name: "credentials"}, {path: "{.status.secretNamespace}"}} {
+		d := &mapFromDataFieldDefinition{
+			secretConfigMapReader: &secretConfigMapReader{
+				secretReader:    secretsReader(f.FakeDynClient()),
+				configMapReader: configMapsReader(f.FakeDynClient()),
+			},
+			objectType: secretObjectType,
+			namespace:  ns,
+			definition: definition{
+				path: "{.status.dbCredentials}",
+			},
+		}
+		val, err := d.Apply(u)
+		require.NoError(t, err)
+		require.Equal(t, expected, val.Get())
+	}
+
+	d := &mapFromDataFieldDefinition{
+		secretConfigMapReader: &secretConfigMapReader{
+			secretReader:    secretsReader(f.FakeDynClient()),
+			configMapReader: configMapsReader(f.FakeDynClient()),
+		},
+		objectType: secretObjectType,
+		definition: definition{
+			path: "{.status.dbCredentials}",
+		},
+	}
+	_, err := d.Apply(u)
+	require.Error(t, err)
+}
+
 func TestMapFromConfigMapDataField(t *testing.T) {
 	f := mocks.NewFake(t, "test-namespace")
 	f.AddMockedUnstructuredConfigMap("dbCredentials-configMap")
@@ -324,7 +373,7 @@ func TestMapFromConfigMapDataField(t *testing.T) {
 		},
 	})
 	require.NoError(t, err)
-	v := map[string]string{
+	v := map[string]interface{}{
 		"username": "user",
 		"password": "password",
 	}
@@ -357,7 +406,7 @@ func TestMapFromConfigMapDataFieldWithOutputNameAndSourceValue(t *testing.T) {
 		},
 	})
 	require.NoError(t, err)
-	v := map[string]string{
+	v := map[string]interface{}{
 		"user": "user",
 	}
 	require.Equal(t, v, val.Get())
@@ -0,0 +1,126 @@
+package binding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+)
+
+// expressionVariable refers to the service resource in expressions
+const expressionVariable = "self"
+
+var (
+	// expressionCostLimit bounds the runtime cost of evaluating an expression, which is indicative of CPU usage
+	expressionCostLimit uint64 = 1000000
+
+	// expressionTimeout bounds the time of evaluating an expression
+	expressionTimeout = time.Second
+)
+
+var (
+	expressionEnv     *cel.Env
+	expressionEnvErr  error
+	expressionEnvOnce sync.Once
+)
+
+// expression is a compiled CEL expression evaluated against service resources
+type expression struct {
+	source  string
+	program cel.Program
+}
+
+func compileExpression(source string) (*expression, error) {
+	expressionEnvOnce.Do(func() {
+		expressionEnv, expressionEnvErr = cel.NewEnv(cel.Variable(expressionVariable, cel.DynType))
+	})
+	if expressionEnvErr != nil {
+		return nil, expressionEnvErr
+	}
+	ast, issues := expressionEnv.Compile(source)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("expr has invalid syntax: %q: %w", source, issues.Err())
+	}
+	// comprehensions check whether evaluation has timed out every given number of iterations
+	program, err := expressionEnv.Program(ast, cel.CostLimit(expressionCostLimit), cel.InterruptCheckFrequency(100))
+	if err != nil {
+		return nil, fmt.Errorf("expr cannot be evaluated: %q: %w", source, err)
+	}
+	return &expression{source: source, program: program}, nil
+}
+
+// eval returns the result of the expression evaluated against the given object,
+// in the same form as values returned by JSONPath lookups
+func (e *expression) eval(obj map[string]interface{}) ([]reflect.Value, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), expressionTimeout)
+	defer cancel()
+	out, _, err := e.program.ContextEval(ctx, map[string]interface{}{expressionVariable: obj})
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate expr %q: %w", e.source, err)
+	}
+	value, err := toNative(out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate expr %q: %w", e.source, err)
+	}
+	return []reflect.Value{reflect.ValueOf(value)}, nil
+}
+
+// toNative converts CEL values into the types used by unstructured objects, unsigned integers
+// out of the range of int64 cannot be represented and are reported as errors
+func toNative(v ref.Val) (interface{}, error) {
+	switch v.Type() {
+	case types.NullType:
+		return nil, nil
+	case types.IntType:
+		return int64(v.(types.Int)), nil
+	case types.UintType:
+		u := uint64(v.(types.Uint))
+		if u > math.MaxInt64 {
+			return nil, fmt.Errorf("unsigned integer %d out of range of int64", u)
+		}
+		return int64(u), nil
+	case types.DoubleType:
+		return float64(v.(types.Double)), nil
+	case types.BoolType:
+		return bool(v.(types.Bool)), nil
+	case types.StringType:
+		return string(v.(types.String)), nil
+	case types.BytesType:
+		return string(v.(types.Bytes)), nil
+	}
+	switch c := v.(type) {
+	case traits.Lister:
+		var res []interface{}
+		for it := c.Iterator(); it.HasNext() == types.True; {
+			e, err := toNative(it.Next())
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, e)
+		}
+		return res, nil
+	case traits.Mapper:
+		res := make(map[string]interface{})
+		for it := c.Iterator(); it.HasNext() == types.True; {
+			k := it.Next()
+			key, err := toNative(k)
+			if err != nil {
+				return nil, err
+			}
+			value, err := toNative(c.Get(k))
+			if err != nil {
+				return nil, err
+			}
+			res[fmt.Sprintf("%v", key)] = value
+		}
+		return res, nil
+	}
+	return v.Value(), nil
+}
@@ -0,0 +1,190 @@
+package binding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestExpressionDefinitions(t *testing.T) {
+	service := func(spec map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": spec,
+			"status": map[string]interface{}{
+				"host": "example.com",
+				"port": int64(6379),
+			},
+		}}
+	}
+
+	testCases := []struct {
+		description string
+		name        string
+		value       string
+		service     *unstructured.Unstructured
+		expected    map[string]interface{}
+	}{
+		{
+			description: "conditional string",
+			name:        "service.binding/scheme",
+			value:       "expr=has(self.spec.tls) ? 'rediss' : 'redis'",
+			service:     service(map[string]interface{}{"tls": true}),
+			expected:    map[string]interface{}{"scheme": "rediss"},
+		},
+		{
+			description: "conditional string without optional field",
+			name:        "service.binding/scheme",
+			value:       "expr=has(self.spec.tls) ? 'rediss' : 'redis'",
+			service:     service(map[string]interface{}{}),
+			expected:    map[string]interface{}{"scheme": "redis"},
+		},
+		{
+			description: "string with default value",
+			name:        "service.binding/user",
+			value:       "expr=has(self.spec.user) ? self.spec.user : 'admin'",
+			service:     service(map[string]interface{}{}),
+			expected:    map[string]interface{}{"user": "admin"},
+		},
+		{
+			description: "string built from several fields",
+			name:        "service.binding/url",
+			value:       "expr=self.status.host + ':' + string(self.status.port)",
+			service:     service(map[string]interface{}{}),
+			expected:    map[string]interface{}{"url": "example.com:6379"},
+		},
+		{
+			description: "number",
+			name:        "service.binding/port",
+			value:       "expr=self.status.port",
+			service:     service(map[string]interface{}{}),
+			expected:    map[string]interface{}{"port": int64(6379)},
+		},
+		{
+			description: "expression containing commas followed by other keys",
+			name:        "service.binding/second",
+			value:       "expr=['a', 'b', self.status.host][2],optional=true",
+			service:     service(map[string]interface{}{}),
+			expected:    map[string]interface{}{"second": "example.com"},
+		},
+		{
+			description: "expression containing keys in string literals",
+			name:        "service.binding/query",
+			value:       "expr=self.status.host + '?a=1,path=x,sourceKey=y' + \"&b=\\\",expr=\"",
+			service:     service(map[string]interface{}{}),
+			expected:    map[string]interface{}{"query": "example.com?a=1,path=x,sourceKey=y&b=\",expr="},
+		},
+		{
+			description: "expression containing keys in string literals followed by other keys",
+			name:        "service.binding/query",
+			value:       "expr=self.status.host + '?a=1,optional=false',optional=true",
+			service:     service(map[string]interface{}{}),
+			expected:    map[string]interface{}{"query": "example.com?a=1,optional=false"},
+		},
+		{
+			description: "map",
+			name:        "service.binding/endpoint",
+			value:       "elementType=map,expr={'host': self.status.host, 'port': string(self.status.port)}",
+			service:     service(map[string]interface{}{}),
+			expected: map[string]interface{}{"endpoint": map[string]interface{}{
+				"host": "example.com",
+				"port": "6379",
+			}},
+		},
+		{
+			description: "map without output name",
+			name:        "service.binding",
+			value:       "expr={'host': self.status.host},elementType=map",
+			service:     service(map[string]interface{}{}),
+			expected:    map[string]interface{}{"host": "example.com"},
+		},
+		{
+			description: "slice of strings",
+			name:        "service.binding/hosts",
+			value:       "expr=self.spec.nodes.filter(n, n.ready).map(n, n.host),elementType=sliceOfStrings",
+			service: service(map[string]interface{}{"nodes": []interface{}{
+				map[string]interface{}{"host": "a", "ready": true},
+				map[string]interface{}{"host": "b", "ready": false},
+				map[string]interface{}{"host": "c", "ready": true},
+			}}),
+			expected: map[string]interface{}{"hosts": []interface{}{"a", "c"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			d, err := NewDefinitionBuilder(tc.name, tc.value, nil, nil).Build()
+			require.NoError(t, err)
+			v, err := d.Apply(tc.service)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, v.Get())
+		})
+	}
+}
+
+func TestOptionalExpression(t *testing.T) {
+	d, err := NewDefinitionBuilder("service.binding/user", "expr=self.spec.user,optional=true", nil, nil).Build()
+	require.NoError(t, err)
+	v, err := d.Apply(&unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}})
+	require.NoError(t, err)
+	require.True(t, d.NonExistingOptional(v))
+
+	d, err = NewDefinitionBuilder("service.binding/user", "expr=self.spec.user", nil, nil).Build()
+	require.NoError(t, err)
+	_, err = d.Apply(&unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}})
+	require.Error(t, err)
+}
+
+func TestExpressionLimits(t *testing.T) {
+	nodes := make([]interface{}, 1000)
+	for i := range nodes {
+		nodes[i] = int64(i)
+	}
+	svc := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{"nodes": nodes}}}
+	value := "expr=self.spec.nodes.filter(n, n % 2 == 0).size()"
+
+	d, err := NewDefinitionBuilder("service.binding/size", value, nil, nil).Build()
+	require.NoError(t, err)
+	v, err := d.Apply(svc)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"size": int64(500)}, v.Get())
+
+	defer func(timeout time.Duration) { expressionTimeout = timeout }(expressionTimeout)
+	expressionTimeout = time.Nanosecond
+	_, err = d.Apply(svc)
+	require.ErrorContains(t, err, "interrupted")
+
+	defer func(limit uint64) { expressionCostLimit = limit }(expressionCostLimit)
+	expressionCostLimit = 100
+	d, err = NewDefinitionBuilder("service.binding/size", value, nil, nil).Build()
+	require.NoError(t, err)
+	_, err = d.Apply(svc)
+	require.ErrorContains(t, err, "cost limit exceeded")
+}
+
+func TestUnsignedExpressions(t *testing.T) {
+	svc := &unstructured.Unstructured{Object: map[string]interface{}{}}
+
+	d, err := NewDefinitionBuilder("service.binding/size", "expr=uint(9223372036854775807)", nil, nil).Build()
+	require.NoError(t, err)
+	value, err := d.Apply(svc)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"size": int64(9223372036854775807)}, value.Get())
+
+	d, err = NewDefinitionBuilder("service.binding/size", "expr=uint(9223372036854775807) + 1u", nil, nil).Build()
+	require.NoError(t, err)
+	_, err = d.Apply(svc)
+	require.ErrorContains(t, err, "unsigned integer 9223372036854775808 out of range of int64")
+}
+
+func TestInvalidExpressions(t *testing.T) {
+	for _, value := range []string{
+		"expr=self.spec.(",
+		"expr=",
+		"path={.spec.user},expr=self.spec.user",
+	} {
+		_, err := NewDefinitionBuilder("service.binding/user", value, nil, nil).Build()
+		require.Error(t, err, value)
+	}
+}
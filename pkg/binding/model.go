@@ -8,10 +8,13 @@ import (
 
 type model struct {
 	path        string
+	expr        *expression
+	transforms  []transformStep
 	elementType elementType
 	objectType  objectType
 	sourceKey   string
 	sourceValue string
+	namespace   dataNamespace
 	value       string
 	optional    bool
 }
@@ -40,13 +43,66 @@ func (m *model) hasDataField() bool {
 	return m.objectType == secretObjectType || m.objectType == configMapObjectType
 }
 
-var keys = []modelKey{pathModelKey, optionalKey, objectTypeModelKey, elementTypeModelKey, sourceKeyModelKey, sourceValueModelKey}
+var keys = []modelKey{pathModelKey, optionalKey, objectTypeModelKey, elementTypeModelKey, sourceKeyModelKey, sourceValueModelKey, namespaceModelKey, namespacePathModelKey}
+
+// greedyKeys are keys whose values can contain commas
+var greedyKeys = []modelKey{exprModelKey, transformModelKey}
+
+// splitGreedy separates the value of the given greedy key from the rest of the annotation value;
+// the value can contain commas, hence it spans until the next known key outside of quoted string literals
+func splitGreedy(annotationValue string, key modelKey) (string, string, bool) {
+	prefix := fmt.Sprintf("%v=", key)
+	start := -1
+	if strings.HasPrefix(annotationValue, prefix) {
+		start = 0
+	} else if i := unquotedKeyIndex(annotationValue, 0, key); i >= 0 {
+		start = i + 1
+	}
+	if start < 0 {
+		return "", annotationValue, false
+	}
+	end := unquotedKeyIndex(annotationValue, start+len(prefix), append(keys, greedyKeys...)...)
+	if end < 0 {
+		end = len(annotationValue)
+	}
+	rest := annotationValue[:start] + strings.TrimPrefix(annotationValue[end:], ",")
+	return annotationValue[start+len(prefix) : end], rest, true
+}
+
+// unquotedKeyIndex returns the index of the first comma following the given index and preceding any of the given keys,
+// skipping string literals quoted by single or double quotes, or -1 if there is no such comma
+func unquotedKeyIndex(annotationValue string, from int, keys ...modelKey) int {
+	var quote byte
+	for i := from; i < len(annotationValue); i++ {
+		c := annotationValue[i]
+		switch {
+		case quote != 0 && c == '\\':
+			// escaped character
+			i++
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ',':
+			for _, k := range keys {
+				if strings.HasPrefix(annotationValue[i+1:], fmt.Sprintf("%v=", k)) {
+					return i
+				}
+			}
+		}
+	}
+	return -1
+}
 
 func newModel(annotationValue string) (*model, error) {
 
 	raw := make(map[modelKey]string)
 
-	for _, kv := range strings.Split(annotationValue, ",") {
+	exprSource, rest, hasExpr := splitGreedy(annotationValue, exprModelKey)
+	pipeline, rest, hasTransform := splitGreedy(rest, transformModelKey)
+
+	for _, kv := range strings.Split(rest, ",") {
 		for i := range keys {
 			k := keys[i]
 			prefix := fmt.Sprintf("%v=", k)
@@ -56,20 +112,36 @@ func newModel(annotationValue string) (*model, error) {
 		}
 	}
 
-	// assert PathModelKey is present
+	// assert either PathModelKey or ExprModelKey is present
 	path, found := raw[pathModelKey]
-	if !found {
-		if len(raw) == 0 {
+	var expr *expression
+	if hasExpr {
+		if found {
+			return nil, fmt.Errorf("path and expr are mutually exclusive: %q", annotationValue)
+		}
+		var err error
+		if expr, err = compileExpression(exprSource); err != nil {
+			return nil, err
+		}
+	} else if !found {
+		if len(raw) == 0 && !hasTransform {
 			return &model{
 				value: annotationValue,
 			}, nil
 		}
 		return nil, fmt.Errorf("path not found: %q", annotationValue)
-	}
-	if n := strings.Count(path, "{"); n == 0 || n != strings.Count(path, "}") {
+	} else if n := strings.Count(path, "{"); n == 0 || n != strings.Count(path, "}") {
 		return nil, fmt.Errorf("path has invalid syntax: %q", path)
 	}
 
+	var transforms []transformStep
+	if hasTransform {
+		var err error
+		if transforms, err = parseTransforms(pipeline); err != nil {
+			return nil, err
+		}
+	}
+
 	// ensure ObjectTypeModelKey has a default value
 	var objType objectType
 	if rawObjectType, found := raw[objectTypeModelKey]; !found {
@@ -108,6 +180,24 @@ func newModel(annotationValue string) (*model, error) {
 	hasData := objType == secretObjectType || objType == configMapObjectType
 	// hasSourceKey indicates a value for sourceKey has been informed
 
+	// namespace of the referenced Secret or ConfigMap, defaults to the namespace of the service
+	namespace, hasNamespace := raw[namespaceModelKey]
+	namespacePath, hasNamespacePath := raw[namespacePathModelKey]
+	if hasNamespace || hasNamespacePath {
+		if !hasData {
+			return nil, fmt.Errorf("namespace and namespacePath require Secret or ConfigMap objectType: %q", annotationValue)
+		}
+		if hasNamespace && hasNamespacePath {
+			return nil, fmt.Errorf("namespace and namespacePath are mutually exclusive: %q", annotationValue)
+		}
+		if hasNamespace && namespace == "" {
+			return nil, fmt.Errorf("namespace cannot be empty: %q", annotationValue)
+		}
+		if n := strings.Count(namespacePath, "{"); hasNamespacePath && (n == 0 || n != strings.Count(namespacePath, "}")) {
+			return nil, fmt.Errorf("namespacePath has invalid syntax: %q", namespacePath)
+		}
+	}
+
 	var eltType elementType
 	if rawEltType, found := raw[elementTypeModelKey]; found {
 		// the input string contains an elementType configuration, use it
@@ -130,10 +220,13 @@ func newModel(annotationValue string) (*model, error) {
 
 	return &model{
 		path:        path,
+		expr:        expr,
+		transforms:  transforms,
 		elementType: eltType,
 		objectType:  objType,
 		sourceValue: sourceValue,
 		sourceKey:   sourceKey,
+		namespace:   dataNamespace{name: namespace, path: namespacePath},
 		optional:    optional,
 	}, nil
 }
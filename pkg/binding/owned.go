@@ -0,0 +1,250 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var ErrOwnedValueNotFound = errors.New("Not found")
+
+// OwnedKind describes resources owned by services that are looked up when binding resources are detected,
+// i.e. the detectBindingResources option of service bindings is enabled
+type OwnedKind struct {
+	schema.GroupVersionResource
+	Kind string
+
+	// Path of the value collected from owned resources, e.g. spec.host
+	Path string
+
+	// Name of the binding item holding the collected value, entries of collected maps are
+	// collected as binding items if empty
+	Name string
+
+	// Extract reads the collected value from the whole resource instead of the path
+	Extract func(*unstructured.Unstructured) (map[string]interface{}, bool, error)
+
+	// Definitions are binding annotations applied to owned resources instead of the path,
+	// e.g. `service.binding/host: path={.spec.rules[0].host}`
+	Definitions map[string]string
+}
+
+func (k OwnedKind) GroupVersionKind() schema.GroupVersionKind {
+	return k.GroupVersionResource.GroupVersion().WithKind(k.Kind)
+}
+
+// Bindable returns true if values are collected from resources of this kind, otherwise resources of this kind
+// are only traversed to look up resources they own in turn, e.g. services owned by a statefulset owned by a service
+func (k OwnedKind) Bindable() bool {
+	return k.Path != "" || k.Extract != nil || len(k.Definitions) > 0
+}
+
+// Value returns the value collected from the given resource at the path or with the extract function,
+// resources of kinds with definitions are collected by applying binding definitions built from them instead
+func (k OwnedKind) Value(u *unstructured.Unstructured) (interface{}, error) {
+	var val interface{}
+	var found bool
+	var err error
+	if k.Extract != nil {
+		val, found, err = k.Extract(u)
+	} else {
+		val, found, err = unstructured.NestedFieldNoCopy(u.Object, strings.Split(k.Path, ".")...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, ErrOwnedValueNotFound
+	}
+	return val, nil
+}
+
+var defaultOwnedKinds = []OwnedKind{
+	{
+		GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		Kind:                 "ConfigMap",
+		Extract:              ConfigMapEntries,
+	},
+	{
+		GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "secrets"},
+		Kind:                 "Secret",
+		Extract:              SecretEntries,
+	},
+	{
+		GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "services"},
+		Kind:                 "Service",
+		Path:                 "spec.clusterIP",
+		Name:                 "clusterIP",
+	},
+	{
+		GroupVersionResource: schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"},
+		Kind:                 "Route",
+		Path:                 "spec.host",
+		Name:                 "host",
+	},
+}
+
+// TraversedOwnedKinds are kinds of owned resources only traversed to look up resources they own in turn,
+// registered when ownership chains longer than direct ownership are followed
+var TraversedOwnedKinds = []OwnedKind{
+	{
+		GroupVersionResource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"},
+		Kind:                 "StatefulSet",
+	},
+	{
+		GroupVersionResource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+		Kind:                 "Deployment",
+	},
+}
+
+// OwnedKindRegistry holds the kinds of owned resources looked up when detecting binding resources
+type OwnedKindRegistry struct {
+	lock  sync.RWMutex
+	kinds []OwnedKind
+
+	// kinds configured at runtime by name, replacing registered kinds of the same group, version and resource
+	profiles map[string]OwnedKind
+}
+
+// Kinds of owned resources looked up when detecting binding resources
+var OwnedKinds = NewOwnedKindRegistry()
+
+// NewOwnedKindRegistry creates a registry holding built-in kinds: data of config maps and secrets,
+// cluster IPs of services and hosts of routes
+func NewOwnedKindRegistry() *OwnedKindRegistry {
+	r := &OwnedKindRegistry{profiles: make(map[string]OwnedKind)}
+	for _, k := range defaultOwnedKinds {
+		r.Register(k)
+	}
+	return r
+}
+
+// Register adds the given kind, or replaces the kind of the same group, version and resource
+func (r *OwnedKindRegistry) Register(kind OwnedKind) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.kinds = replaceOrAppend(r.kinds, kind)
+}
+
+// Remove removes the kind of the given group, version and resource
+func (r *OwnedKindRegistry) Remove(gvr schema.GroupVersionResource) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for i := range r.kinds {
+		if r.kinds[i].GroupVersionResource == gvr {
+			r.kinds = append(r.kinds[:i], r.kinds[i+1:]...)
+			return
+		}
+	}
+}
+
+// Retain keeps only bindable kinds of the given group resources, e.g. `secrets` or `routes.route.openshift.io`,
+// returning an error if any of them is not registered. Kinds only traversed are kept.
+func (r *OwnedKindRegistry) Retain(groupResources ...string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	retained := make(map[string]bool, len(groupResources))
+	for _, gr := range groupResources {
+		if gr = strings.TrimSpace(gr); gr != "" {
+			retained[gr] = false
+		}
+	}
+	var kinds []OwnedKind
+	for _, k := range r.kinds {
+		if !k.Bindable() {
+			kinds = append(kinds, k)
+			continue
+		}
+		gr := k.GroupResource().String()
+		if _, ok := retained[gr]; ok {
+			retained[gr] = true
+			kinds = append(kinds, k)
+		}
+	}
+	for gr, found := range retained {
+		if !found {
+			return fmt.Errorf("unknown owned resource %q", gr)
+		}
+	}
+	r.kinds = kinds
+	return nil
+}
+
+// SetProfile adds or replaces the kind configured under the given name, which takes precedence over
+// the registered kind of the same group, version and resource. Kinds of profiles are never retained out.
+func (r *OwnedKindRegistry) SetProfile(name string, kind OwnedKind) error {
+	if kind.Resource == "" || kind.Version == "" || kind.Kind == "" {
+		return fmt.Errorf("version, kind and resource of owned resources are required")
+	}
+	if len(kind.Definitions) == 0 {
+		return fmt.Errorf("no binding definitions for owned %v", kind.GroupResource())
+	}
+	for k, v := range kind.Definitions {
+		if valid, err := IsServiceBindingAnnotation(k); err != nil {
+			return err
+		} else if !valid {
+			return fmt.Errorf("%q is not a binding annotation", k)
+		}
+		if _, err := NewDefinitionBuilder(k, v, nil, nil).Build(); err != nil {
+			return err
+		}
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.profiles[name] = kind
+	return nil
+}
+
+// RemoveProfile removes the kind configured under the given name
+func (r *OwnedKindRegistry) RemoveProfile(name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.profiles, name)
+}
+
+// Kinds returns the registered kinds in registration order, followed by kinds of profiles ordered by name
+func (r *OwnedKindRegistry) Kinds() []OwnedKind {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.kindsLocked()
+}
+
+func (r *OwnedKindRegistry) kindsLocked() []OwnedKind {
+	kinds := append([]OwnedKind(nil), r.kinds...)
+	names := make([]string, 0, len(r.profiles))
+	for name := range r.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		kinds = replaceOrAppend(kinds, r.profiles[name])
+	}
+	return kinds
+}
+
+func replaceOrAppend(kinds []OwnedKind, kind OwnedKind) []OwnedKind {
+	for i := range kinds {
+		if kinds[i].GroupVersionResource == kind.GroupVersionResource {
+			kinds[i] = kind
+			return kinds
+		}
+	}
+	return append(kinds, kind)
+}
+
+// Lookup returns the registered kind of the given group, version and kind
+func (r *OwnedKindRegistry) Lookup(gvk schema.GroupVersionKind) (OwnedKind, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for _, k := range r.kindsLocked() {
+		if k.GroupVersionKind() == gvk {
+			return k, true
+		}
+	}
+	return OwnedKind{}, false
+}
@@ -0,0 +1,161 @@
+package binding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var ingressKind = OwnedKind{
+	GroupVersionResource: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+	Kind:                 "Ingress",
+	Path:                 "spec.rules",
+	Name:                 "rules",
+}
+
+func TestOwnedKindRegistry(t *testing.T) {
+	t.Run("default kinds", func(t *testing.T) {
+		r := NewOwnedKindRegistry()
+		var bindable []string
+		for _, k := range r.Kinds() {
+			if k.Bindable() {
+				bindable = append(bindable, k.Kind)
+			}
+		}
+		require.Equal(t, []string{"ConfigMap", "Secret", "Service", "Route"}, bindable)
+		require.Len(t, r.Kinds(), 4)
+
+		k, ok := r.Lookup(schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"})
+		require.True(t, ok)
+		require.Equal(t, "host", k.Name)
+
+		_, ok = r.Lookup(ingressKind.GroupVersionKind())
+		require.False(t, ok)
+	})
+
+	t.Run("register", func(t *testing.T) {
+		r := NewOwnedKindRegistry()
+		r.Register(ingressKind)
+		k, ok := r.Lookup(ingressKind.GroupVersionKind())
+		require.True(t, ok)
+		require.Equal(t, "rules", k.Name)
+
+		replaced := ingressKind
+		replaced.Name = "ingressRules"
+		r.Register(replaced)
+		require.Len(t, r.Kinds(), 5)
+		k, _ = r.Lookup(ingressKind.GroupVersionKind())
+		require.Equal(t, "ingressRules", k.Name)
+
+		r.Remove(ingressKind.GroupVersionResource)
+		require.Len(t, r.Kinds(), 4)
+	})
+
+	t.Run("retain", func(t *testing.T) {
+		r := NewOwnedKindRegistry()
+		require.NoError(t, r.Retain("secrets", " routes.route.openshift.io", ""))
+		var kinds []string
+		for _, k := range r.Kinds() {
+			kinds = append(kinds, k.Kind)
+		}
+		require.Equal(t, []string{"Secret", "Route"}, kinds)
+	})
+
+	t.Run("retain unknown", func(t *testing.T) {
+		r := NewOwnedKindRegistry()
+		require.EqualError(t, r.Retain("secrets", "foos.bar"), `unknown owned resource "foos.bar"`)
+		require.Len(t, r.Kinds(), 4)
+	})
+
+	t.Run("retain traversed", func(t *testing.T) {
+		r := NewOwnedKindRegistry()
+		for _, k := range TraversedOwnedKinds {
+			r.Register(k)
+		}
+		require.NoError(t, r.Retain("secrets"))
+		var kinds []string
+		for _, k := range r.Kinds() {
+			kinds = append(kinds, k.Kind)
+		}
+		require.Equal(t, []string{"Secret", "StatefulSet", "Deployment"}, kinds)
+		require.EqualError(t, r.Retain("statefulsets.apps"), `unknown owned resource "statefulsets.apps"`)
+	})
+}
+
+func TestOwnedKindRegistryProfiles(t *testing.T) {
+	ingressProfile := OwnedKind{
+		GroupVersionResource: ingressKind.GroupVersionResource,
+		Kind:                 "Ingress",
+		Definitions:          map[string]string{"service.binding/host": "path={.spec.rules[0].host}"},
+	}
+	serviceProfile := OwnedKind{
+		GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "services"},
+		Kind:                 "Service",
+		Definitions:          map[string]string{"service.binding/ports": "path={.spec.ports},elementType=sliceOfMaps,sourceKey=name,sourceValue=port"},
+	}
+
+	t.Run("set and remove", func(t *testing.T) {
+		r := NewOwnedKindRegistry()
+		require.NoError(t, r.Retain("secrets"))
+		require.NoError(t, r.SetProfile("ingress", ingressProfile))
+		require.NoError(t, r.SetProfile("service", serviceProfile))
+
+		var kinds []string
+		for _, k := range r.Kinds() {
+			kinds = append(kinds, k.Kind)
+		}
+		require.Equal(t, []string{"Secret", "Ingress", "Service"}, kinds)
+		k, ok := r.Lookup(ingressProfile.GroupVersionKind())
+		require.True(t, ok)
+		require.True(t, k.Bindable())
+		require.Equal(t, ingressProfile.Definitions, k.Definitions)
+
+		r.RemoveProfile("ingress")
+		_, ok = r.Lookup(ingressProfile.GroupVersionKind())
+		require.False(t, ok)
+	})
+
+	t.Run("replace registered kind", func(t *testing.T) {
+		r := NewOwnedKindRegistry()
+		require.NoError(t, r.SetProfile("service", serviceProfile))
+		require.Len(t, r.Kinds(), 4)
+		k, _ := r.Lookup(schema.GroupVersionKind{Version: "v1", Kind: "Service"})
+		require.Equal(t, serviceProfile.Definitions, k.Definitions)
+
+		r.RemoveProfile("service")
+		k, _ = r.Lookup(schema.GroupVersionKind{Version: "v1", Kind: "Service"})
+		require.Equal(t, "clusterIP", k.Name)
+		require.Empty(t, k.Definitions)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		r := NewOwnedKindRegistry()
+		invalid := ingressProfile
+		invalid.Definitions = map[string]string{"service.binding/host": "path={.spec.rules[0].host},elementType=foo"}
+		require.Error(t, r.SetProfile("ingress", invalid))
+		invalid.Definitions = map[string]string{"foo": "bar"}
+		require.EqualError(t, r.SetProfile("ingress", invalid), `"foo" is not a binding annotation`)
+		invalid.Definitions = nil
+		require.Error(t, r.SetProfile("ingress", invalid))
+		invalid = ingressProfile
+		invalid.Resource = ""
+		require.Error(t, r.SetProfile("ingress", invalid))
+		_, ok := r.Lookup(ingressProfile.GroupVersionKind())
+		require.False(t, ok)
+	})
+}
+
+func TestOwnedKindValue(t *testing.T) {
+	route := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{"host": "example.com"},
+	}}
+	k, _ := NewOwnedKindRegistry().Lookup(schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"})
+	val, err := k.Value(route)
+	require.NoError(t, err)
+	require.Equal(t, "example.com", val)
+
+	_, err = k.Value(&unstructured.Unstructured{Object: map[string]interface{}{}})
+	require.Equal(t, ErrOwnedValueNotFound, err)
+}
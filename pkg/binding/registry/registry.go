@@ -1,107 +1,223 @@
 package registry
 
-import "k8s.io/apimachinery/pkg/runtime/schema"
+import (
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
 
 type Registry interface {
+	// GetAnnotations returns annotations of the most specific entry matching the given service kind and version,
+	// merged with annotations of matching profiles
 	GetAnnotations(serviceGVK schema.GroupVersionKind) (map[string]string, bool)
 	Register(serviceGVK schema.GroupVersionKind, annotations map[string]string)
+
+	// SetProfile adds or replaces the named profile, returns error if its versions cannot be parsed
+	SetProfile(name string, profile Profile) error
+	RemoveProfile(name string)
+
+	// GetProfile returns the named profile, false if no such profile is set
+	GetProfile(name string) (Profile, bool)
+}
+
+// Profile declares annotations of all versions of a service kind matching the given versions
+type Profile struct {
+	Group string
+	Kind  string
+	// Versions is either `*` (or empty) for all versions, an exact version, a comma separated list of versions,
+	// or a range such as `>=v1beta1 <v2` or `>=1.8.0 <1.11.0`
+	Versions    string
+	Annotations map[string]string
+}
+
+type profile struct {
+	Profile
+	versions *versionMatcher
+}
+
+type entry struct {
+	versions    *versionMatcher
+	annotations map[string]string
+}
+
+type builtIn struct {
+	groupKind   schema.GroupKind
+	versions    string
+	annotations map[string]string
 }
 
 type impl struct {
-	annotationMap map[schema.GroupVersionKind]map[string]string
+	lock sync.RWMutex
+	// built-in annotations, act as defaults for profiles
+	annotationMap map[schema.GroupKind][]entry
+	profiles      map[string]*profile
 }
 
 var ServiceAnnotations = New()
 
-func New() Registry {
-	return &impl{
-		annotationMap: map[schema.GroupVersionKind]map[string]string{
-			schema.GroupVersionKind{Group: "redis.redis.opstreelabs.in", Version: "v1beta1", Kind: "Redis"}: {
-				"service.binding/type":     "redis",
-				"service.binding/host":     "path={.metadata.name}",
-				"service.binding/password": "path={.spec.kubernetesConfig.redisSecret.name},objectType=Secret,sourceKey=password,optional=true",
-			},
-			schema.GroupVersionKind{Group: "postgres-operator.crunchydata.com", Version: "v1beta1", Kind: "PostgresCluster"}: {
-				"service.binding/type":     "postgresql",
-				"service.binding/provider": "crunchydata",
-				"service.binding":          "path={.metadata.name}-pguser-{.metadata.name},objectType=Secret",
-				"service.binding/database": "path={.metadata.name}-pguser-{.metadata.name},objectType=Secret,sourceKey=dbname",
-				"service.binding/username": "path={.metadata.name}-pguser-{.metadata.name},objectType=Secret,sourceKey=user",
-				"service.binding/cert":     "path={.metadata.name}-cluster-cert,objectType=Secret",
-			},
-			schema.GroupVersionKind{Group: "pxc.percona.com", Version: "v1-8-0", Kind: "PerconaXtraDBCluster"}: {
-				"service.binding/type":     "mysql",
-				"service.binding/provider": "percona",
-				"service.binding/database": "mysql",
-				"service.binding":          "path={.spec.secretsName},objectType=Secret",
-				"service.binding/host":     "path={.status.host}",
-				"service.binding/port":     "3306",
-				"service.binding/username": "root",
-				"service.binding/password": "path={.spec.secretsName},objectType=Secret,sourceKey=root",
-			},
-			schema.GroupVersionKind{Group: "pxc.percona.com", Version: "v1-9-0", Kind: "PerconaXtraDBCluster"}: {
-				"service.binding/type":     "mysql",
-				"service.binding/provider": "percona",
-				"service.binding/database": "mysql",
-				"service.binding":          "path={.spec.secretsName},objectType=Secret",
-				"service.binding/host":     "path={.status.host}",
-				"service.binding/port":     "3306",
-				"service.binding/username": "root",
-				"service.binding/password": "path={.spec.secretsName},objectType=Secret,sourceKey=root",
-			},
-			schema.GroupVersionKind{Group: "pxc.percona.com", Version: "v1-10-0", Kind: "PerconaXtraDBCluster"}: {
-				"service.binding/type":     "mysql",
-				"service.binding/provider": "percona",
-				"service.binding/database": "mysql",
-				"service.binding":          "path={.spec.secretsName},objectType=Secret",
-				"service.binding/host":     "path={.status.host}",
-				"service.binding/port":     "3306",
-				"service.binding/username": "root",
-				"service.binding/password": "path={.spec.secretsName},objectType=Secret,sourceKey=root",
-			},
-			schema.GroupVersionKind{Group: "psmdb.percona.com", Version: "v1-9-0", Kind: "PerconaServerMongoDB"}: {
-				"service.binding/type":     "mongodb",
-				"service.binding/provider": "percona",
-				"service.binding":          "path={.spec.secrets.users},objectType=Secret",
-				"service.binding/username": "path={.spec.secrets.users},objectType=Secret,sourceKey=MONGODB_USER_ADMIN_USER",
-				"service.binding/password": "path={.spec.secrets.users},objectType=Secret,sourceKey=MONGODB_USER_ADMIN_PASSWORD",
-				"service.binding/host":     "path={.status.host}",
-			},
-			schema.GroupVersionKind{Group: "psmdb.percona.com", Version: "v1-10-0", Kind: "PerconaServerMongoDB"}: {
-				"service.binding/type":     "mongodb",
-				"service.binding/provider": "percona",
-				"service.binding":          "path={.spec.secrets.users},objectType=Secret",
-				"service.binding/username": "path={.spec.secrets.users},objectType=Secret,sourceKey=MONGODB_USER_ADMIN_USER",
-				"service.binding/password": "path={.spec.secrets.users},objectType=Secret,sourceKey=MONGODB_USER_ADMIN_PASSWORD",
-				"service.binding/host":     "path={.status.host}",
-			},
-			schema.GroupVersionKind{Group: "psmdb.percona.com", Version: "v1", Kind: "PerconaServerMongoDB"}: {
-				"service.binding/type":     "mongodb",
-				"service.binding/provider": "percona",
-				"service.binding":          "path={.spec.secrets.users},objectType=Secret",
-				"service.binding/username": "path={.spec.secrets.users},objectType=Secret,sourceKey=MONGODB_USER_ADMIN_USER",
-				"service.binding/password": "path={.spec.secrets.users},objectType=Secret,sourceKey=MONGODB_USER_ADMIN_PASSWORD",
-				"service.binding/host":     "path={.status.host}",
-			},
-			schema.GroupVersionKind{Group: "postgresql.k8s.enterprisedb.io", Version: "v1", Kind: "Cluster"}: {
-				"service.binding/type":     "postgresql",
-				"service.binding/host":     "path={.status.writeService}",
-				"service.binding/provider": "enterprisedb",
-				"service.binding":          "path={.metadata.name}-{.spec.bootstrap.initdb.owner},objectType=Secret",
-				"service.binding/database": "path={.spec.bootstrap.initdb.database}",
-			},
-			schema.GroupVersionKind{Group: "rabbitmq.com", Version: "v1beta1", Kind: "RabbitmqCluster"}: {
-				"servicebinding.io/provisioned-service": "true",
-			},
+var builtIns = []builtIn{
+	{
+		groupKind: schema.GroupKind{Group: "redis.redis.opstreelabs.in", Kind: "Redis"},
+		versions:  "v1beta1",
+		annotations: map[string]string{
+			"service.binding/type":     "redis",
+			"service.binding/host":     "path={.metadata.name}",
+			"service.binding/password": "path={.spec.kubernetesConfig.redisSecret.name},objectType=Secret,sourceKey=password,optional=true",
+		},
+	},
+	{
+		groupKind: schema.GroupKind{Group: "postgres-operator.crunchydata.com", Kind: "PostgresCluster"},
+		versions:  "v1beta1",
+		annotations: map[string]string{
+			"service.binding/type":     "postgresql",
+			"service.binding/provider": "crunchydata",
+			"service.binding":          "path={.metadata.name}-pguser-{.metadata.name},objectType=Secret",
+			"service.binding/database": "path={.metadata.name}-pguser-{.metadata.name},objectType=Secret,sourceKey=dbname",
+			"service.binding/username": "path={.metadata.name}-pguser-{.metadata.name},objectType=Secret,sourceKey=user",
+			"service.binding/cert":     "path={.metadata.name}-cluster-cert,objectType=Secret",
+		},
+	},
+	{
+		groupKind: schema.GroupKind{Group: "pxc.percona.com", Kind: "PerconaXtraDBCluster"},
+		versions:  "v1-8-0,v1-9-0,v1-10-0",
+		annotations: map[string]string{
+			"service.binding/type":     "mysql",
+			"service.binding/provider": "percona",
+			"service.binding/database": "mysql",
+			"service.binding":          "path={.spec.secretsName},objectType=Secret",
+			"service.binding/host":     "path={.status.host}",
+			"service.binding/port":     "3306",
+			"service.binding/username": "root",
+			"service.binding/password": "path={.spec.secretsName},objectType=Secret,sourceKey=root",
 		},
+	},
+	{
+		groupKind: schema.GroupKind{Group: "psmdb.percona.com", Kind: "PerconaServerMongoDB"},
+		versions:  "v1-9-0,v1-10-0,v1",
+		annotations: map[string]string{
+			"service.binding/type":     "mongodb",
+			"service.binding/provider": "percona",
+			"service.binding":          "path={.spec.secrets.users},objectType=Secret",
+			"service.binding/username": "path={.spec.secrets.users},objectType=Secret,sourceKey=MONGODB_USER_ADMIN_USER",
+			"service.binding/password": "path={.spec.secrets.users},objectType=Secret,sourceKey=MONGODB_USER_ADMIN_PASSWORD",
+			"service.binding/host":     "path={.status.host}",
+		},
+	},
+	{
+		groupKind: schema.GroupKind{Group: "postgresql.k8s.enterprisedb.io", Kind: "Cluster"},
+		versions:  "v1",
+		annotations: map[string]string{
+			"service.binding/type":     "postgresql",
+			"service.binding/host":     "path={.status.writeService}",
+			"service.binding/provider": "enterprisedb",
+			"service.binding":          "path={.metadata.name}-{.spec.bootstrap.initdb.owner},objectType=Secret",
+			"service.binding/database": "path={.spec.bootstrap.initdb.database}",
+		},
+	},
+	{
+		groupKind: schema.GroupKind{Group: "rabbitmq.com", Kind: "RabbitmqCluster"},
+		versions:  "v1beta1",
+		annotations: map[string]string{
+			"servicebinding.io/provisioned-service": "true",
+		},
+	},
+}
+
+func New() Registry {
+	r := &impl{
+		annotationMap: make(map[schema.GroupKind][]entry),
+		profiles:      make(map[string]*profile),
+	}
+	for _, b := range builtIns {
+		versions, err := parseVersions(b.versions)
+		if err != nil {
+			panic(err)
+		}
+		r.annotationMap[b.groupKind] = append(r.annotationMap[b.groupKind], entry{versions: versions, annotations: b.annotations})
 	}
+	return r
 }
 
 func (i *impl) GetAnnotations(serviceGVK schema.GroupVersionKind) (map[string]string, bool) {
-	result, found := i.annotationMap[serviceGVK]
-	return result, found
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	var result map[string]string
+	found := false
+	best := -1
+	for _, e := range i.annotationMap[serviceGVK.GroupKind()] {
+		// later entries win among equally specific ones
+		if specificity, ok := e.versions.match(serviceGVK.Version); ok && specificity >= best {
+			result, found, best = e.annotations, true, specificity
+		}
+	}
+
+	type profileMatch struct {
+		name        string
+		specificity int
+	}
+	var matches []profileMatch
+	for name, p := range i.profiles {
+		if p.Group != serviceGVK.Group || p.Kind != serviceGVK.Kind {
+			continue
+		}
+		if specificity, ok := p.versions.match(serviceGVK.Version); ok {
+			matches = append(matches, profileMatch{name: name, specificity: specificity})
+		}
+	}
+	if len(matches) == 0 {
+		return result, found
+	}
+	// more specific profiles override less specific ones, equally specific ones are merged in name order
+	sort.Slice(matches, func(a, b int) bool {
+		if matches[a].specificity != matches[b].specificity {
+			return matches[a].specificity < matches[b].specificity
+		}
+		return matches[a].name < matches[b].name
+	})
+	merged := make(map[string]string)
+	for k, v := range result {
+		merged[k] = v
+	}
+	for _, m := range matches {
+		for k, v := range i.profiles[m.name].Annotations {
+			merged[k] = v
+		}
+	}
+	return merged, true
 }
 
 func (i *impl) Register(serviceGVK schema.GroupVersionKind, annotations map[string]string) {
-	i.annotationMap[serviceGVK] = annotations
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	versions := &versionMatcher{terms: []versionTerm{{exact: serviceGVK.Version}}}
+	gk := serviceGVK.GroupKind()
+	i.annotationMap[gk] = append(i.annotationMap[gk], entry{versions: versions, annotations: annotations})
+}
+
+func (i *impl) SetProfile(name string, p Profile) error {
+	versions, err := parseVersions(p.Versions)
+	if err != nil {
+		return err
+	}
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.profiles[name] = &profile{Profile: p, versions: versions}
+	return nil
+}
+
+func (i *impl) RemoveProfile(name string) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	delete(i.profiles, name)
+}
+
+func (i *impl) GetProfile(name string) (Profile, bool) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+	p, ok := i.profiles[name]
+	if !ok {
+		return Profile{}, false
+	}
+	return p.Profile, true
 }
@@ -0,0 +1,98 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func pxc(version string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "pxc.percona.com", Version: version, Kind: "PerconaXtraDBCluster"}
+}
+
+func schemaGVK(group string, version string, kind string) schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: group, Version: version, Kind: kind}
+}
+
+func TestBuiltInAnnotations(t *testing.T) {
+	r := New()
+	anns, found := r.GetAnnotations(pxc("v1-9-0"))
+	require.True(t, found)
+	require.Equal(t, "mysql", anns["service.binding/type"])
+
+	_, found = r.GetAnnotations(pxc("v1-11-0"))
+	require.False(t, found)
+}
+
+func TestProfileVersions(t *testing.T) {
+	testCases := []struct {
+		versions string
+		matching []string
+		other    []string
+	}{
+		{versions: "", matching: []string{"v1", "v1beta1", "v1-11-0"}},
+		{versions: "*", matching: []string{"v1", "v1beta1", "v1-11-0"}},
+		{versions: "v1-11-0", matching: []string{"v1-11-0"}, other: []string{"v1-12-0", "v1"}},
+		{versions: "v1beta1", matching: []string{"v1beta1"}, other: []string{"v1beta2", "v1"}},
+		{versions: ">=1.11.0 <1.13.0", matching: []string{"v1-11-0", "v1-12-5"}, other: []string{"v1-7-0", "v1-13-0", "v1beta1"}},
+		{versions: ">=2.0.0", matching: []string{"v2", "v3.1"}, other: []string{"v1", "v2alpha1"}},
+	}
+	for _, tc := range testCases {
+		r := New()
+		require.NoError(t, r.SetProfile("p", Profile{Group: "pxc.percona.com", Kind: "PerconaXtraDBCluster", Versions: tc.versions, Annotations: map[string]string{"service.binding/type": "mysql"}}))
+		for _, v := range tc.matching {
+			_, found := r.GetAnnotations(pxc(v))
+			require.True(t, found, "versions %q should match %s", tc.versions, v)
+		}
+		for _, v := range tc.other {
+			_, found := r.GetAnnotations(pxc(v))
+			require.False(t, found, "versions %q should not match %s", tc.versions, v)
+		}
+		_, found := r.GetAnnotations(schema.GroupVersionKind{Group: "pxc.percona.com", Version: "v1-11-0", Kind: "Other"})
+		require.False(t, found)
+	}
+}
+
+func TestProfilesOverrideBuiltIns(t *testing.T) {
+	r := New()
+	require.NoError(t, r.SetProfile("b", Profile{Group: "pxc.percona.com", Kind: "PerconaXtraDBCluster", Versions: "*", Annotations: map[string]string{"service.binding/port": "3307", "service.binding/host": "path={.status.b}"}}))
+	require.NoError(t, r.SetProfile("a", Profile{Group: "pxc.percona.com", Kind: "PerconaXtraDBCluster", Versions: "*", Annotations: map[string]string{"service.binding/host": "path={.status.a}"}}))
+
+	anns, found := r.GetAnnotations(pxc("v1-9-0"))
+	require.True(t, found)
+	require.Equal(t, "mysql", anns["service.binding/type"])
+	require.Equal(t, "3307", anns["service.binding/port"])
+	require.Equal(t, "path={.status.b}", anns["service.binding/host"])
+
+	builtIn, _ := New().GetAnnotations(pxc("v1-9-0"))
+	require.Equal(t, "3306", builtIn["service.binding/port"])
+
+	p, found := r.GetProfile("a")
+	require.True(t, found)
+	require.Equal(t, "path={.status.a}", p.Annotations["service.binding/host"])
+
+	r.RemoveProfile("a")
+	r.RemoveProfile("b")
+	anns, _ = r.GetAnnotations(pxc("v1-9-0"))
+	require.Equal(t, builtIn, anns)
+	_, found = r.GetProfile("a")
+	require.False(t, found)
+}
+
+func TestInvalidProfileVersions(t *testing.T) {
+	r := New()
+	require.Error(t, r.SetProfile("p", Profile{Group: "g", Kind: "K", Versions: ">=1.x.y"}))
+	_, found := r.GetAnnotations(schema.GroupVersionKind{Group: "g", Version: "v1", Kind: "K"})
+	require.False(t, found)
+}
+
+func TestRegisteredVersionOverridesBuiltInList(t *testing.T) {
+	r := New()
+	r.Register(pxc("v1-9-0"), map[string]string{"service.binding/type": "mariadb"})
+
+	anns, _ := r.GetAnnotations(pxc("v1-9-0"))
+	require.Equal(t, map[string]string{"service.binding/type": "mariadb"}, anns)
+	anns, _ = r.GetAnnotations(pxc("v1-8-0"))
+	require.Equal(t, "mysql", anns["service.binding/type"])
+}
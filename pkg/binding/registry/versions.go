@@ -0,0 +1,153 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// Specificity of version matches, when several entries match a version the most specific one wins
+const (
+	anyVersionMatch = iota
+	rangeMatch
+	listMatch
+	exactMatch
+)
+
+var kubeVersion = regexp.MustCompile(`^v(\d+)((alpha|beta)(\d+))?$`)
+
+// versionMatcher matches versions against `*`, an exact version, a comma separated list of versions
+// or ranges such as `>=v1beta1 <v2` or `>=1.8.0 <1.11.0`
+type versionMatcher struct {
+	terms []versionTerm
+}
+
+// versionTerm is either an exact version or a conjunction of bounds
+type versionTerm struct {
+	exact  string
+	bounds []versionBound
+}
+
+type versionBound struct {
+	operator string
+	version  string
+}
+
+func parseVersions(versions string) (*versionMatcher, error) {
+	versions = strings.TrimSpace(versions)
+	if versions == "" || versions == "*" {
+		return &versionMatcher{}, nil
+	}
+	m := &versionMatcher{}
+	for _, t := range strings.FieldsFunc(strings.ReplaceAll(versions, "||", ","), func(r rune) bool { return r == ',' }) {
+		t = strings.TrimSpace(t)
+		if t == "" {
+			continue
+		}
+		term := versionTerm{}
+		if !strings.ContainsAny(t, "<> ") {
+			term.exact = t
+		} else {
+			for _, f := range strings.Fields(t) {
+				b, err := parseBound(f)
+				if err != nil {
+					return nil, fmt.Errorf("invalid versions %q: %w", versions, err)
+				}
+				term.bounds = append(term.bounds, b)
+			}
+		}
+		m.terms = append(m.terms, term)
+	}
+	if len(m.terms) == 0 {
+		return nil, fmt.Errorf("invalid versions %q", versions)
+	}
+	return m, nil
+}
+
+func parseBound(s string) (versionBound, error) {
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(s, op) {
+			v := strings.TrimPrefix(s, op)
+			if _, err := toSemver(v); err != nil {
+				return versionBound{}, fmt.Errorf("cannot parse version %q", v)
+			}
+			return versionBound{operator: op, version: v}, nil
+		}
+	}
+	return versionBound{}, fmt.Errorf("missing comparison operator in %q", s)
+}
+
+// match returns the specificity of the match, or false if the version does not match
+func (m *versionMatcher) match(v string) (int, bool) {
+	if len(m.terms) == 0 {
+		return anyVersionMatch, true
+	}
+	specificity := -1
+	for _, t := range m.terms {
+		if t.exact != "" {
+			if compareVersions(t.exact, v) == 0 {
+				if len(m.terms) == 1 {
+					return exactMatch, true
+				}
+				specificity = listMatch
+			}
+		} else if t.matches(v) && specificity < rangeMatch {
+			specificity = rangeMatch
+		}
+	}
+	return specificity, specificity >= 0
+}
+
+func (t *versionTerm) matches(v string) bool {
+	for _, b := range t.bounds {
+		c := compareVersions(v, b.version)
+		switch b.operator {
+		case ">=":
+			if c < 0 {
+				return false
+			}
+		case "<=":
+			if c > 0 {
+				return false
+			}
+		case ">":
+			if c <= 0 {
+				return false
+			}
+		case "<":
+			if c >= 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// compareVersions compares Kubernetes versions by their priority, i.e. v1alpha1 < v2alpha1 < v1beta1 < v1 < v2,
+// other versions are compared semantically, with alpha and beta versions being pre-releases
+func compareVersions(a string, b string) int {
+	if a == b {
+		return 0
+	}
+	if kubeVersion.MatchString(a) && kubeVersion.MatchString(b) {
+		return version.CompareKubeAwareVersionStrings(a, b)
+	}
+	sa, errA := toSemver(a)
+	sb, errB := toSemver(b)
+	if errA == nil && errB == nil {
+		return sa.Compare(sb)
+	}
+	return strings.Compare(a, b)
+}
+
+// toSemver converts versions such as `v1`, `v1.2`, `v1-10-0` or `v2beta1` into semantic versions,
+// the latter being converted into `2.0.0-beta.1`
+func toSemver(v string) (semver.Version, error) {
+	if m := kubeVersion.FindStringSubmatch(v); m != nil && m[2] != "" {
+		return semver.ParseTolerant(fmt.Sprintf("%s.0.0-%s.%s", m[1], m[3], m[4]))
+	}
+	return semver.ParseTolerant(strings.ReplaceAll(v, "-", "."))
+}
@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionMatcher(t *testing.T) {
+	testCases := []struct {
+		versions    string
+		version     string
+		specificity int
+		matching    bool
+	}{
+		{versions: "*", version: "v1beta1", specificity: anyVersionMatch, matching: true},
+		{versions: "v1beta1", version: "v1beta1", specificity: exactMatch, matching: true},
+		{versions: "v1beta1", version: "v1beta2", matching: false},
+		{versions: "v1-8-0,v1-9-0", version: "v1-9-0", specificity: listMatch, matching: true},
+		{versions: "v1-8-0,v1-9-0", version: "v1-10-0", matching: false},
+		{versions: ">=v1beta1", version: "v1", specificity: rangeMatch, matching: true},
+		{versions: ">=v1beta1", version: "v1alpha1", matching: false},
+		{versions: ">=v1beta1", version: "v2alpha1", matching: false},
+		{versions: ">v1alpha1 <v1", version: "v1beta2", specificity: rangeMatch, matching: true},
+		{versions: ">v1alpha1 <v1", version: "v1", matching: false},
+		{versions: ">=1.8.0 <1.11.0", version: "v1-10-0", specificity: rangeMatch, matching: true},
+		{versions: ">=1.8.0 <1.11.0", version: "v1-11-0", matching: false},
+		{versions: "<=v1-9-0, v1-12-0", version: "v1-8-0", specificity: rangeMatch, matching: true},
+		{versions: "<=v1-9-0, v1-12-0", version: "v1-12-0", specificity: listMatch, matching: true},
+		{versions: "<=v1-9-0 || >=v1-12-0", version: "v1-13-0", specificity: rangeMatch, matching: true},
+	}
+	for _, tc := range testCases {
+		m, err := parseVersions(tc.versions)
+		require.NoError(t, err)
+		specificity, matching := m.match(tc.version)
+		require.Equal(t, tc.matching, matching, "%q matching %s", tc.versions, tc.version)
+		if tc.matching {
+			require.Equal(t, tc.specificity, specificity, "%q matching %s", tc.versions, tc.version)
+		}
+	}
+}
+
+func TestInvalidVersions(t *testing.T) {
+	for _, versions := range []string{">=1.x.y", "1.0.0 2.0.0", ">= v1", ","} {
+		_, err := parseVersions(versions)
+		require.Error(t, err, versions)
+	}
+}
+
+func TestMostSpecificEntryWins(t *testing.T) {
+	r := New()
+	require.NoError(t, r.SetProfile("any", Profile{Group: "g", Kind: "K", Versions: "*", Annotations: map[string]string{"a": "any", "b": "any", "c": "any", "d": "any"}}))
+	require.NoError(t, r.SetProfile("exact", Profile{Group: "g", Kind: "K", Versions: "v1", Annotations: map[string]string{"a": "exact"}}))
+	require.NoError(t, r.SetProfile("list", Profile{Group: "g", Kind: "K", Versions: "v1,v2", Annotations: map[string]string{"a": "list", "b": "list"}}))
+	require.NoError(t, r.SetProfile("range", Profile{Group: "g", Kind: "K", Versions: ">=v1beta1", Annotations: map[string]string{"a": "range", "b": "range", "c": "range"}}))
+
+	anns, found := r.GetAnnotations(schemaGVK("g", "v1", "K"))
+	require.True(t, found)
+	require.Equal(t, map[string]string{"a": "exact", "b": "list", "c": "range", "d": "any"}, anns)
+}
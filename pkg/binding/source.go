@@ -0,0 +1,179 @@
+package binding
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Service whose binding definitions are looked up
+type Service interface {
+	// Service resource
+	Resource() *unstructured.Unstructured
+
+	// Custom resource definition of the service, nil if the service is not a custom resource
+	CustomResourceDefinition() (*unstructured.Unstructured, error)
+
+	// OLM descriptor of the service custom resource, nil if no CSV owning the CRD is found
+	Descriptor() (*olmv1alpha1.CRDDescription, error)
+}
+
+// DefinitionSource provides binding definitions declared for services,
+// expressed as service.binding annotations mapped to their values
+type DefinitionSource interface {
+	// Returns binding definitions declared for the given service, nil if there are none
+	Definitions(service Service) (map[string]string, error)
+}
+
+// Precedence of built-in definition sources. Definitions from sources with higher precedence
+// override definitions with the same key from sources with lower precedence.
+const (
+	DescriptorSourcePrecedence = 100
+	TemplateSourcePrecedence   = 200
+	AnnotationSourcePrecedence = 300
+)
+
+type registeredSource struct {
+	precedence int
+	source     DefinitionSource
+}
+
+// DefinitionSourceRegistry merges binding definitions of registered sources according to their precedence
+type DefinitionSourceRegistry struct {
+	lock    sync.RWMutex
+	sources []registeredSource
+}
+
+// Sources consulted when collecting binding definitions of services
+var DefinitionSources = NewDefinitionSourceRegistry()
+
+func NewDefinitionSourceRegistry() *DefinitionSourceRegistry {
+	r := &DefinitionSourceRegistry{}
+	r.Register(DescriptorSourcePrecedence, DescriptorSource{})
+	r.Register(AnnotationSourcePrecedence, AnnotationSource{})
+	return r
+}
+
+// Register adds a source with the given precedence, sources with the same precedence are consulted in registration order
+func (r *DefinitionSourceRegistry) Register(precedence int, source DefinitionSource) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.sources = append(r.sources, registeredSource{precedence: precedence, source: source})
+	sort.SliceStable(r.sources, func(i, j int) bool {
+		return r.sources[i].precedence < r.sources[j].precedence
+	})
+}
+
+// Definitions returns binding definitions of the given service merged from all registered sources
+func (r *DefinitionSourceRegistry) Definitions(service Service) (map[string]string, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	result := make(map[string]string)
+	for _, s := range r.sources {
+		definitions, err := s.source.Definitions(service)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range definitions {
+			result[k] = v
+		}
+	}
+	return result, nil
+}
+
+// AnnotationSource provides definitions declared through annotations on service CRDs and resources,
+// annotations on service resources override those on CRDs
+type AnnotationSource struct{}
+
+var _ DefinitionSource = AnnotationSource{}
+
+func (AnnotationSource) Definitions(service Service) (map[string]string, error) {
+	result := make(map[string]string)
+	crd, err := service.CustomResourceDefinition()
+	if err != nil {
+		return nil, err
+	}
+	if crd != nil {
+		for k, v := range crd.GetAnnotations() {
+			result[k] = v
+		}
+	}
+	for k, v := range service.Resource().GetAnnotations() {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// DescriptorSource provides definitions declared through service.binding x-descriptors
+// in the OLM ClusterServiceVersion owning the service CRD
+type DescriptorSource struct{}
+
+var _ DefinitionSource = DescriptorSource{}
+
+func (DescriptorSource) Definitions(service Service) (map[string]string, error) {
+	descriptor, err := service.Descriptor()
+	if err != nil || descriptor == nil {
+		return nil, err
+	}
+	return DescriptorAnnotations(descriptor), nil
+}
+
+// DescriptorAnnotations converts service.binding x-descriptors of the given CRD description into equivalent annotations
+func DescriptorAnnotations(crdDescription *olmv1alpha1.CRDDescription) map[string]string {
+	anns := make(map[string]string)
+	for _, sd := range crdDescription.StatusDescriptors {
+		objectType := getObjectType(sd.XDescriptors)
+		for _, xd := range sd.XDescriptors {
+			loadDescriptor(anns, sd.Path, xd, "status", objectType)
+		}
+	}
+
+	for _, sd := range crdDescription.SpecDescriptors {
+		objectType := getObjectType(sd.XDescriptors)
+		for _, xd := range sd.XDescriptors {
+			loadDescriptor(anns, sd.Path, xd, "spec", objectType)
+		}
+	}
+	return anns
+}
+
+func getObjectType(descriptors []string) string {
+	typeAnno := "urn:alm:descriptor:io.kubernetes:"
+	for _, desc := range descriptors {
+		if strings.HasPrefix(desc, typeAnno) {
+			return strings.TrimPrefix(desc, typeAnno)
+		}
+	}
+	return ""
+}
+
+func loadDescriptor(anns map[string]string, path string, descriptor string, root string, objectType string) {
+	if !strings.HasPrefix(descriptor, AnnotationPrefix) {
+		return
+	}
+
+	keys := strings.Split(descriptor, ":")
+	key := AnnotationPrefix
+	value := ""
+
+	if len(keys) > 1 {
+		key += "/" + keys[1]
+	} else {
+		key += "/" + path
+	}
+
+	p := []string{fmt.Sprintf("path={.%s.%s}", root, path)}
+	if len(keys) > 1 {
+		p = append(p, keys[2:]...)
+	}
+	if objectType != "" {
+		p = append(p, []string{fmt.Sprintf("objectType=%s", objectType)}...)
+	}
+
+	value += strings.Join(p, ",")
+	anns[key] = value
+}
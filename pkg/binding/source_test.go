@@ -0,0 +1,128 @@
+package binding
+
+import (
+	"errors"
+	"testing"
+
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type testService struct {
+	resource   *unstructured.Unstructured
+	crd        *unstructured.Unstructured
+	descriptor *olmv1alpha1.CRDDescription
+	err        error
+}
+
+func (s *testService) Resource() *unstructured.Unstructured {
+	return s.resource
+}
+
+func (s *testService) CustomResourceDefinition() (*unstructured.Unstructured, error) {
+	return s.crd, s.err
+}
+
+func (s *testService) Descriptor() (*olmv1alpha1.CRDDescription, error) {
+	return s.descriptor, s.err
+}
+
+type staticSource map[string]string
+
+func (s staticSource) Definitions(Service) (map[string]string, error) {
+	return s, nil
+}
+
+func annotated(anns map[string]string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.com/v1alpha1")
+	u.SetKind("Database")
+	u.SetAnnotations(anns)
+	return u
+}
+
+func TestDefinitionSourcesMergedByPrecedence(t *testing.T) {
+	r := &DefinitionSourceRegistry{}
+	r.Register(30, staticSource{"service.binding/a": "high", "service.binding/c": "high"})
+	r.Register(10, staticSource{"service.binding/a": "low", "service.binding/b": "low"})
+	r.Register(20, staticSource{"service.binding/b": "mid"})
+
+	result, err := r.Definitions(&testService{resource: annotated(nil)})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"service.binding/a": "high",
+		"service.binding/b": "mid",
+		"service.binding/c": "high",
+	}, result)
+}
+
+func TestDefaultDefinitionSources(t *testing.T) {
+	service := &testService{
+		resource: annotated(map[string]string{"service.binding/user": "path={.spec.username}"}),
+		crd:      annotated(map[string]string{"service.binding/user": "path={.spec.user}", "service.binding/host": "path={.spec.host}"}),
+		descriptor: &olmv1alpha1.CRDDescription{
+			StatusDescriptors: []olmv1alpha1.StatusDescriptor{
+				{Path: "host", XDescriptors: []string{"service.binding:host"}},
+				{Path: "port", XDescriptors: []string{"service.binding:port"}},
+			},
+		},
+	}
+
+	result, err := NewDefinitionSourceRegistry().Definitions(service)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"service.binding/user": "path={.spec.username}",
+		"service.binding/host": "path={.spec.host}",
+		"service.binding/port": "path={.status.port}",
+	}, result)
+}
+
+func TestDefinitionSourcesError(t *testing.T) {
+	err := errors.New("foo")
+	_, actual := NewDefinitionSourceRegistry().Definitions(&testService{resource: annotated(nil), err: err})
+	require.Equal(t, err, actual)
+}
+
+func TestTemplateSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1alpha1.AddToScheme(scheme))
+	template := func(name string, spec v1alpha1.BindingDefinitionTemplateSpec) *v1alpha1.BindingDefinitionTemplate {
+		return &v1alpha1.BindingDefinitionTemplate{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		template("a", v1alpha1.BindingDefinitionTemplateSpec{
+			Group:       "example.com",
+			Kind:        "Database",
+			Definitions: map[string]string{"service.binding/host": "path={.spec.host}", "service.binding/port": "path={.spec.port}"},
+		}),
+		template("b", v1alpha1.BindingDefinitionTemplateSpec{
+			Group:       "example.com",
+			Kind:        "Database",
+			Versions:    []string{"v1alpha1"},
+			Definitions: map[string]string{"service.binding/port": "path={.status.port}"},
+		}),
+		template("c", v1alpha1.BindingDefinitionTemplateSpec{
+			Group:       "example.com",
+			Kind:        "Database",
+			Versions:    []string{"v1"},
+			Definitions: map[string]string{"service.binding/user": "path={.spec.user}"},
+		}),
+		template("d", v1alpha1.BindingDefinitionTemplateSpec{
+			Group:       "other.com",
+			Kind:        "Database",
+			Definitions: map[string]string{"service.binding/password": "path={.spec.password}"},
+		}),
+	).Build()
+
+	result, err := NewTemplateSource(c).Definitions(&testService{resource: annotated(nil)})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"service.binding/host": "path={.spec.host}",
+		"service.binding/port": "path={.status.port}",
+	}, result)
+}
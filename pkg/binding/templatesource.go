@@ -0,0 +1,46 @@
+package binding
+
+import (
+	"context"
+	"sort"
+
+	"github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TemplateSource provides definitions declared through BindingDefinitionTemplate resources,
+// templates are merged in name order
+type TemplateSource struct {
+	client client.Reader
+}
+
+var _ DefinitionSource = &TemplateSource{}
+
+func NewTemplateSource(client client.Reader) *TemplateSource {
+	return &TemplateSource{client: client}
+}
+
+func (s *TemplateSource) Definitions(service Service) (map[string]string, error) {
+	templates := &v1alpha1.BindingDefinitionTemplateList{}
+	if err := s.client.List(context.Background(), templates); err != nil {
+		return nil, err
+	}
+	gvk := service.Resource().GroupVersionKind()
+	result := make(map[string]string)
+	for _, t := range sortedTemplates(templates.Items) {
+		if !t.Spec.Matches(gvk.Group, gvk.Version, gvk.Kind) {
+			continue
+		}
+		for k, v := range t.Spec.Definitions {
+			result[k] = v
+		}
+	}
+	return result, nil
+}
+
+func sortedTemplates(templates []v1alpha1.BindingDefinitionTemplate) []v1alpha1.BindingDefinitionTemplate {
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates
+}
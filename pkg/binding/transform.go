@@ -0,0 +1,292 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+)
+
+// TransformFunc transforms a binding value
+type TransformFunc func(value interface{}) (interface{}, error)
+
+// TransformFactory creates a transform for the given argument, returns error if the argument is invalid
+type TransformFactory func(arg string) (TransformFunc, error)
+
+var (
+	transformsLock sync.RWMutex
+	transforms     = map[string]TransformFactory{
+		"base64Encode": noArgTransform("base64Encode", base64Encode),
+		"base64Decode": noArgTransform("base64Decode", base64Decode),
+		"json":         extractTransform("json", json.Unmarshal),
+		"yaml":         extractTransform("yaml", unmarshalYAML),
+		"url":          urlTransform,
+		"trim":         trimTransform,
+		"lowercase":    noArgTransform("lowercase", lowercase),
+		"pemBundle":    noArgTransform("pemBundle", pemBundle),
+	}
+)
+
+// RegisterTransform makes the transform available under the given name in transform= pipelines
+func RegisterTransform(name string, factory TransformFactory) {
+	transformsLock.Lock()
+	defer transformsLock.Unlock()
+	transforms[name] = factory
+}
+
+type transformStep struct {
+	name string
+	fn   TransformFunc
+}
+
+// parseTransforms parses transform pipelines such as `json({.uri})|trim|lowercase`
+func parseTransforms(pipeline string) ([]transformStep, error) {
+	transformsLock.RLock()
+	defer transformsLock.RUnlock()
+	var steps []transformStep
+	for _, s := range splitPipeline(pipeline) {
+		s = strings.TrimSpace(s)
+		name, arg := s, ""
+		if i := strings.Index(s, "("); i >= 0 {
+			if !strings.HasSuffix(s, ")") {
+				return nil, fmt.Errorf("transform has invalid syntax: %q", s)
+			}
+			name, arg = s[:i], s[i+1:len(s)-1]
+		}
+		factory, found := transforms[name]
+		if !found {
+			return nil, fmt.Errorf("unknown transform: %q", name)
+		}
+		fn, err := factory(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transform %q: %w", s, err)
+		}
+		steps = append(steps, transformStep{name: name, fn: fn})
+	}
+	if len(steps) == 0 {
+		return nil, errors.New("transform requires at least one step")
+	}
+	return steps, nil
+}
+
+// splitPipeline splits pipeline steps separated by | outside of parentheses
+func splitPipeline(pipeline string) []string {
+	var steps []string
+	depth, start := 0, 0
+	for i, c := range pipeline {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '|':
+			if depth == 0 {
+				steps = append(steps, pipeline[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(pipeline) {
+		steps = append(steps, pipeline[start:])
+	}
+	return steps
+}
+
+func applyTransforms(steps []transformStep, value interface{}) (interface{}, error) {
+	var err error
+	for _, s := range steps {
+		if value, err = s.fn(value); err != nil {
+			return nil, fmt.Errorf("transform %s failed: %w", s.name, err)
+		}
+	}
+	return value, nil
+}
+
+// transformedDefinition applies transforms on values collected by the wrapped definition
+type transformedDefinition struct {
+	Definition
+	outputName string
+	transforms []transformStep
+}
+
+var _ Definition = (*transformedDefinition)(nil)
+
+func (d *transformedDefinition) Apply(u *unstructured.Unstructured) (Value, error) {
+	v, err := d.Definition.Apply(u)
+	if err != nil || v.Get() == nil {
+		return v, err
+	}
+	m, ok := v.Get().(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot transform value %v", v.Get())
+	}
+	res := make(map[string]interface{})
+	for k, e := range m {
+		// without binding name each entry is a separate binding item,
+		// values read from secret or config map entries are keyed by the empty string
+		if d.outputName == "" || k == d.outputName || k == "" {
+			if e, err = applyTransforms(d.transforms, e); err != nil {
+				return nil, err
+			}
+		}
+		res[k] = e
+	}
+	return &value{v: res}, nil
+}
+
+func noArgTransform(name string, fn TransformFunc) TransformFactory {
+	return func(arg string) (TransformFunc, error) {
+		if arg != "" {
+			return nil, fmt.Errorf("%s does not accept arguments", name)
+		}
+		return fn, nil
+	}
+}
+
+func asString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func base64Encode(value interface{}) (interface{}, error) {
+	return base64.StdEncoding.EncodeToString([]byte(asString(value))), nil
+}
+
+func base64Decode(value interface{}) (interface{}, error) {
+	decoded, err := base64.StdEncoding.DecodeString(asString(value))
+	if err != nil {
+		return nil, err
+	}
+	return string(decoded), nil
+}
+
+func lowercase(value interface{}) (interface{}, error) {
+	return strings.ToLower(asString(value)), nil
+}
+
+// trimTransform trims whitespaces, or the characters given as argument
+func trimTransform(arg string) (TransformFunc, error) {
+	return func(value interface{}) (interface{}, error) {
+		if arg == "" {
+			return strings.TrimSpace(asString(value)), nil
+		}
+		return strings.Trim(asString(value), arg), nil
+	}, nil
+}
+
+// extractTransform extracts the field given by the JSONPath template argument from serialized documents
+func extractTransform(name string, unmarshal func([]byte, interface{}) error) TransformFactory {
+	return func(arg string) (TransformFunc, error) {
+		if arg == "" {
+			return nil, fmt.Errorf("%s requires a JSONPath template argument", name)
+		}
+		j := jsonpath.New(name)
+		if err := j.Parse(arg); err != nil {
+			return nil, err
+		}
+		return func(value interface{}) (interface{}, error) {
+			var doc interface{}
+			if err := unmarshal([]byte(asString(value)), &doc); err != nil {
+				return nil, err
+			}
+			res, err := j.FindResults(doc)
+			if err != nil {
+				return nil, err
+			}
+			if len(res) != 1 || len(res[0]) != 1 {
+				return nil, fmt.Errorf("only one value should be returned for %v", arg)
+			}
+			return res[0][0].Interface(), nil
+		}, nil
+	}
+}
+
+func unmarshalYAML(data []byte, v interface{}) error {
+	return yaml.Unmarshal(data, v)
+}
+
+// urlTransform builds URLs from maps with host, port, username, password and database or path entries,
+// the argument being the URL scheme
+func urlTransform(scheme string) (TransformFunc, error) {
+	return func(value interface{}) (interface{}, error) {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("url requires a map, got %v", value)
+		}
+		entry := func(keys ...string) string {
+			for _, k := range keys {
+				if v, found := m[k]; found && v != nil {
+					return asString(v)
+				}
+			}
+			return ""
+		}
+		u := &url.URL{Scheme: scheme}
+		if u.Scheme == "" {
+			u.Scheme = entry("scheme", "type")
+		}
+		if u.Host = entry("host"); u.Host == "" {
+			return nil, errors.New("url requires a host entry")
+		}
+		if port := entry("port"); port != "" {
+			u.Host = net.JoinHostPort(u.Host, port)
+		}
+		if user := entry("username", "user"); user != "" {
+			if password := entry("password"); password != "" {
+				u.User = url.UserPassword(user, password)
+			} else {
+				u.User = url.User(user)
+			}
+		}
+		if path := entry("database", "path"); path != "" {
+			u.Path = "/" + strings.TrimPrefix(path, "/")
+		}
+		return u.String(), nil
+	}, nil
+}
+
+// pemBundle concatenates PEM encoded certificates from maps, in key order, or from slices
+func pemBundle(value interface{}) (interface{}, error) {
+	var items []interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			items = append(items, v[k])
+		}
+	case []interface{}:
+		items = v
+	default:
+		items = []interface{}{v}
+	}
+	var bundle bytes.Buffer
+	for _, item := range items {
+		data := []byte(strings.TrimSpace(asString(item)))
+		if block, _ := pem.Decode(data); block == nil {
+			return nil, errors.New("pemBundle requires PEM encoded values")
+		}
+		bundle.Write(data)
+		bundle.WriteString("\n")
+	}
+	return bundle.String(), nil
+}
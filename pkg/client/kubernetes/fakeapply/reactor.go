@@ -0,0 +1,261 @@
+// Package fakeapply emulates server-side apply on fake dynamic clients, which do not support applying unstructured objects
+package fakeapply
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// listKeyFunc returns the key identifying elements of the given list field, or an empty string if the list is atomic
+type listKeyFunc func(field string) string
+
+// AddReactor makes the given client handle apply patches sent by the given field manager: the applied configuration
+// is merged into the object, fields previously applied but missing from the configuration are removed, and managed fields
+// of the manager are recorded. Fields are assumed not to be shared with other managers. Lists are merged by key only if
+// they are known map lists, i.e. lists of pod templates of built-in workloads, other lists being atomic as lists of
+// custom resources are by default.
+func AddReactor(client *fake.FakeDynamicClient, manager string) {
+	client.PrependReactor("patch", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch, ok := action.(k8stesting.PatchAction)
+		if !ok || patch.GetPatchType() != types.ApplyPatchType || patch.GetSubresource() != "" {
+			return false, nil, nil
+		}
+		config := &unstructured.Unstructured{}
+		if err := config.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, errors.NewBadRequest(err.Error())
+		}
+		tracker := client.Tracker()
+		gvr := action.GetResource()
+		keys := func(field string) string {
+			return kubernetes.MapListKey(gvr.Group, field)
+		}
+		ns := action.GetNamespace()
+		existing, err := tracker.Get(gvr, ns, patch.GetName())
+		if errors.IsNotFound(err) {
+			obj := config.DeepCopy()
+			obj.SetResourceVersion("")
+			setManagedFields(obj, manager, config, keys)
+			return true, obj, tracker.Create(gvr, obj, ns)
+		}
+		if err != nil {
+			return true, nil, err
+		}
+		obj, err := toUnstructured(existing)
+		if err != nil {
+			return true, nil, err
+		}
+		if rv := config.GetResourceVersion(); rv != "" && rv != obj.GetResourceVersion() {
+			return true, nil, errors.NewConflict(gvr.GroupResource(), obj.GetName(), fmt.Errorf("the object has been modified"))
+		}
+		for _, mf := range obj.GetManagedFields() {
+			if mf.Manager == manager && mf.Operation == metav1.ManagedFieldsOperationApply && mf.FieldsV1 != nil {
+				var previous map[string]interface{}
+				if err := json.Unmarshal(mf.FieldsV1.Raw, &previous); err != nil {
+					return true, nil, err
+				}
+				remove(obj.Object, previous, fields(config.Object, keys))
+			}
+		}
+		merge(obj.Object, withoutIdentity(config.Object), keys)
+		setManagedFields(obj, manager, config, keys)
+		return true, obj, tracker.Update(gvr, obj, ns)
+	})
+}
+
+func toUnstructured(obj runtime.Object) (*unstructured.Unstructured, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &unstructured.Unstructured{Object: u}, nil
+}
+
+// withoutIdentity strips fields identifying the object, which are not owned by managers
+func withoutIdentity(obj map[string]interface{}) map[string]interface{} {
+	u := (&unstructured.Unstructured{Object: obj}).DeepCopy()
+	delete(u.Object, "apiVersion")
+	delete(u.Object, "kind")
+	for _, f := range []string{"name", "namespace", "resourceVersion", "managedFields"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", f)
+	}
+	if m, _, _ := unstructured.NestedMap(u.Object, "metadata"); len(m) == 0 {
+		delete(u.Object, "metadata")
+	}
+	return u.Object
+}
+
+func setManagedFields(obj *unstructured.Unstructured, manager string, config *unstructured.Unstructured, keys listKeyFunc) {
+	raw, _ := json.Marshal(fields(config.Object, keys))
+	entry := metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		APIVersion: config.GetAPIVersion(),
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: raw},
+	}
+	var result []metav1.ManagedFieldsEntry
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Manager != manager || mf.Operation != metav1.ManagedFieldsOperationApply {
+			result = append(result, mf)
+		}
+	}
+	obj.SetManagedFields(append(result, entry))
+}
+
+// fields returns the managed fields set, as described by metav1.FieldsV1, of the given configuration
+func fields(config map[string]interface{}, keys listKeyFunc) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range withoutIdentity(config) {
+		result["f:"+k] = valueFields(v, keys(k), keys)
+	}
+	return result
+}
+
+// valueFields returns the fields set of the given value, atomic lists being owned as a whole
+func valueFields(value interface{}, key string, keys listKeyFunc) map[string]interface{} {
+	result := make(map[string]interface{})
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, fv := range v {
+			result["f:"+k] = valueFields(fv, keys(k), keys)
+		}
+	case []interface{}:
+		if key == "" || !keyed(v, key) {
+			break
+		}
+		for _, e := range v {
+			m := e.(map[string]interface{})
+			k, _ := json.Marshal(map[string]interface{}{key: m[key]})
+			ef := valueFields(m, "", keys)
+			ef["."] = map[string]interface{}{}
+			result["k:"+string(k)] = ef
+		}
+	}
+	return result
+}
+
+// remove deletes from the object the fields of the previous set which are not part of the current one
+func remove(obj interface{}, previous, current map[string]interface{}) {
+	for k, p := range previous {
+		pf, _ := p.(map[string]interface{})
+		cf, inCurrent := current[k].(map[string]interface{})
+		switch v := obj.(type) {
+		case map[string]interface{}:
+			if len(k) < 2 || k[:2] != "f:" {
+				continue
+			}
+			if !inCurrent {
+				delete(v, k[2:])
+			} else if child, found := v[k[2:]]; found {
+				remove(child, pf, cf)
+				if l, isList := v[k[2:]].([]interface{}); isList {
+					v[k[2:]] = removeElements(l, pf, cf)
+				}
+			}
+		case []interface{}:
+			if inCurrent {
+				for _, e := range v {
+					if matches(e, k) {
+						remove(e, pf, cf)
+					}
+				}
+			}
+		}
+	}
+}
+
+func removeElements(list []interface{}, previous, current map[string]interface{}) []interface{} {
+	result := []interface{}{}
+	for _, e := range list {
+		removed := false
+		for k := range previous {
+			if _, inCurrent := current[k]; !inCurrent && matches(e, k) {
+				removed = true
+			}
+		}
+		if !removed {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func matches(e interface{}, key string) bool {
+	m, ok := e.(map[string]interface{})
+	if !ok || len(key) < 2 || key[:2] != "k:" {
+		return false
+	}
+	var keys map[string]interface{}
+	if err := json.Unmarshal([]byte(key[2:]), &keys); err != nil {
+		return false
+	}
+	for kf, kv := range keys {
+		if !reflect.DeepEqual(m[kf], kv) {
+			return false
+		}
+	}
+	return true
+}
+
+// merge merges the configuration into the object, elements of map lists being merged by their keys
+// and atomic lists being replaced
+func merge(obj map[string]interface{}, config map[string]interface{}, keys listKeyFunc) {
+	for k, cv := range config {
+		switch c := cv.(type) {
+		case map[string]interface{}:
+			if o, ok := obj[k].(map[string]interface{}); ok {
+				merge(o, c, keys)
+				continue
+			}
+		case []interface{}:
+			if key := keys(k); key != "" {
+				if o, ok := obj[k].([]interface{}); ok && keyed(o, key) && keyed(c, key) {
+					obj[k] = mergeList(o, c, key, keys)
+					continue
+				}
+			}
+		}
+		obj[k] = runtime.DeepCopyJSONValue(cv)
+	}
+}
+
+func mergeList(list []interface{}, config []interface{}, key string, keys listKeyFunc) []interface{} {
+	for _, ce := range config {
+		cm := ce.(map[string]interface{})
+		found := false
+		for _, e := range list {
+			if m := e.(map[string]interface{}); m[key] == cm[key] {
+				merge(m, cm, keys)
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, runtime.DeepCopyJSONValue(cm))
+		}
+	}
+	return list
+}
+
+func keyed(list []interface{}, key string) bool {
+	for _, e := range list {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m[key].(string); !ok {
+			return false
+		}
+	}
+	return true
+}
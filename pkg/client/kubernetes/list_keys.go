@@ -0,0 +1,24 @@
+package kubernetes
+
+// builtInWorkloadGroups are API groups of built-in workloads, whose pod templates hold map lists
+var builtInWorkloadGroups = map[string]bool{"": true, "apps": true, "batch": true}
+
+// podTemplateListKeys are merge keys of map lists of pod templates, by list field
+var podTemplateListKeys = map[string]string{
+	"containers":     "name",
+	"initContainers": "name",
+	"env":            "name",
+	"volumes":        "name",
+	"volumeMounts":   "mountPath",
+}
+
+// MapListKey returns the key identifying elements of the given list field of resources of the given API group,
+// or an empty string if the list is not known to be a map list. Lists of pod templates of built-in workloads are
+// map lists, while lists of custom resources are atomic unless their schema declares otherwise, hence lists not
+// known to be map lists have to be applied as a whole.
+func MapListKey(group string, field string) string {
+	if !builtInWorkloadGroups[group] {
+		return ""
+	}
+	return podTemplateListKeys[field]
+}
@@ -0,0 +1,314 @@
+// Package offline runs binding pipelines against manifests held in memory, so that bindings can be checked without a cluster
+package offline
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/redhat-developer/service-binding-operator/apis"
+	"github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/apis/spec/v1beta1"
+	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes"
+	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes/fakeapply"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/builder"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/context"
+	authv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic/fake"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	fakeauth "k8s.io/client-go/kubernetes/typed/authorization/v1/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var (
+	crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+	// kinds read by the pipeline which are not part of client-go scheme
+	extraKinds = []schema.GroupVersionKind{
+		{Group: "route.openshift.io", Version: "v1", Kind: "Route"},
+		{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "ClusterServiceVersion"},
+		crdGVK,
+	}
+)
+
+// Result of processing a service binding
+type Result struct {
+	// Service binding, with its status set by the pipeline
+	Binding *unstructured.Unstructured
+
+	// Binding secret, nil if no secret has been created
+	Secret *unstructured.Unstructured
+
+	// Workloads modified by the pipeline
+	Workloads []*unstructured.Unstructured
+
+	// True if the binding has been processed successfully
+	Ready bool
+
+	// Error returned by the pipeline
+	Err error
+}
+
+// Cluster is an in-memory cluster holding the given manifests
+type Cluster struct {
+	client  *fake.FakeDynamicClient
+	mapper  meta.RESTMapper
+	objects []*unstructured.Unstructured
+}
+
+// Decode reads objects from YAML or JSON documents, flattening lists
+func Decode(r io.Reader) ([]*unstructured.Unstructured, error) {
+	var result []*unstructured.Unstructured
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return result, nil
+			}
+			return nil, err
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.IsList() {
+			err := obj.EachListItem(func(item runtime.Object) error {
+				result = append(result, item.(*unstructured.Unstructured))
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		result = append(result, obj)
+	}
+}
+
+// NewCluster creates a cluster holding the given objects
+func NewCluster(objects []*unstructured.Unstructured) (*Cluster, error) {
+	mapper, listKinds, err := restMapper(objects)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cluster{
+		client: fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds),
+		mapper: mapper,
+	}
+	fakeapply.AddReactor(c.client, context.FieldManager)
+	for _, obj := range objects {
+		obj = obj.DeepCopy()
+		if isServiceBinding(obj) && obj.GetUID() == "" {
+			// status of bindings is persisted only for bindings with UID
+			obj.SetUID(types.UID(fmt.Sprintf("%s-%s", obj.GetNamespace(), obj.GetName())))
+		}
+		gvr, err := c.resource(obj)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = c.client.Resource(gvr).Namespace(obj.GetNamespace()).Create(gocontext.Background(), obj, metav1.CreateOptions{}); err != nil {
+			return nil, err
+		}
+		c.objects = append(c.objects, obj)
+	}
+	return c, nil
+}
+
+// Bind processes all service bindings held by the cluster and returns their results in order of appearance
+func (c *Cluster) Bind(opts ...context.ProviderOption) ([]*Result, error) {
+	var results []*Result
+	for _, obj := range c.objects {
+		if !isServiceBinding(obj) {
+			continue
+		}
+		result, err := c.bind(obj, opts)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (c *Cluster) bind(obj *unstructured.Unstructured, opts []context.ProviderOption) (*Result, error) {
+	typeLookup := kubernetes.ResourceLookup(c.mapper)
+	authClient := allowingAuthClient()
+	var (
+		sb        apis.Object
+		p         pipeline.Pipeline
+		getSecret func() string
+	)
+	switch obj.GroupVersionKind() {
+	case v1alpha1.GroupVersionKind:
+		b := &v1alpha1.ServiceBinding{}
+		if err := fromUnstructured(obj, b); err != nil {
+			return nil, err
+		}
+		if _, found, _ := unstructured.NestedFieldNoCopy(obj.Object, "spec", "bindAsFiles"); !found {
+			// defaulted by the API server
+			b.Spec.BindAsFiles = true
+		}
+		sb = b
+		getSecret = func() string { return b.Status.Secret }
+		p = builder.DefaultBuilder.WithContextProvider(context.Provider(c.client, authClient, typeLookup, opts...)).Build()
+	case v1beta1.GroupVersionKind:
+		b := &v1beta1.ServiceBinding{}
+		if err := fromUnstructured(obj, b); err != nil {
+			return nil, err
+		}
+		sb = b
+		getSecret = func() string {
+			if b.Status.Binding == nil {
+				return ""
+			}
+			return b.Status.Binding.Name
+		}
+		p = builder.SpecBuilder.WithContextProvider(context.SpecProvider(c.client, authClient, typeLookup, opts...)).Build()
+	default:
+		return nil, fmt.Errorf("unsupported service binding %v", obj.GroupVersionKind())
+	}
+
+	retry, _, err := p.Process(gocontext.Background(), sb)
+	if err == nil && retry {
+		err = errors.New("binding has not been completed, processing would be retried")
+	}
+	result := &Result{
+		Ready: err == nil && meta.IsStatusConditionTrue(sb.StatusConditions(), apis.BindingReady),
+		Err:   err,
+	}
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(sb)
+	if err != nil {
+		return nil, err
+	}
+	result.Binding = &unstructured.Unstructured{Object: u}
+	if name := getSecret(); name != "" {
+		secret, err := c.client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}).Namespace(obj.GetNamespace()).Get(gocontext.Background(), name, metav1.GetOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			result.Secret = secret
+		}
+	}
+	for _, o := range c.objects {
+		if isServiceBinding(o) {
+			continue
+		}
+		gvr, err := c.resource(o)
+		if err != nil {
+			return nil, err
+		}
+		current, err := c.client.Resource(gvr).Namespace(o.GetNamespace()).Get(gocontext.Background(), o.GetName(), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		// fields managed by the operator are bookkeeping of the API server, not part of the workload
+		current.SetManagedFields(nil)
+		if !equality.Semantic.DeepEqual(o.Object, current.Object) {
+			result.Workloads = append(result.Workloads, current)
+		}
+	}
+	return result, nil
+}
+
+func (c *Cluster) resource(obj *unstructured.Unstructured) (schema.GroupVersionResource, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := c.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+	return mapping.Resource, nil
+}
+
+func isServiceBinding(obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	return gvk == v1alpha1.GroupVersionKind || gvk == v1beta1.GroupVersionKind
+}
+
+func fromUnstructured(obj *unstructured.Unstructured, target interface{}) error {
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, target)
+}
+
+// restMapper maps kinds of the given objects, of known types and of custom resources defined by the given CRDs,
+// and returns list kinds of all mapped resources
+func restMapper(objects []*unstructured.Unstructured) (meta.RESTMapper, map[schema.GroupVersionResource]string, error) {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	listKinds := make(map[schema.GroupVersionResource]string)
+	add := func(gvk schema.GroupVersionKind, plural schema.GroupVersionResource, singular schema.GroupVersionResource) {
+		if _, found := listKinds[plural]; found {
+			return
+		}
+		mapper.AddSpecific(gvk, plural, singular, meta.RESTScopeNamespace)
+		listKinds[plural] = gvk.Kind + "List"
+	}
+	guess := func(gvk schema.GroupVersionKind) {
+		plural, singular := meta.UnsafeGuessKindToResource(gvk)
+		add(gvk, plural, singular)
+	}
+
+	// plural names of custom resources are given by their definitions
+	for _, obj := range objects {
+		if obj.GroupVersionKind() != crdGVK {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+		plural, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "plural")
+		singular, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "singular")
+		versions, _, err := unstructured.NestedSlice(obj.Object, "spec", "versions")
+		if err != nil {
+			return nil, nil, err
+		}
+		if singular == "" {
+			singular = strings.ToLower(kind)
+		}
+		for _, v := range versions {
+			version, ok := v.(map[string]interface{})["name"].(string)
+			if !ok {
+				continue
+			}
+			gv := schema.GroupVersion{Group: group, Version: version}
+			add(gv.WithKind(kind), gv.WithResource(plural), gv.WithResource(singular))
+		}
+	}
+
+	scheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{clientgoscheme.AddToScheme, v1alpha1.AddToScheme, v1beta1.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, nil, err
+		}
+	}
+	for gvk := range scheme.AllKnownTypes() {
+		if !strings.HasSuffix(gvk.Kind, "List") && !strings.HasSuffix(gvk.Kind, "Options") {
+			guess(gvk)
+		}
+	}
+	for _, gvk := range extraKinds {
+		guess(gvk)
+	}
+	for _, obj := range objects {
+		guess(obj.GroupVersionKind())
+	}
+	return mapper, listKinds, nil
+}
+
+// allowingAuthClient returns a client authorizing any access to resources held by the cluster
+func allowingAuthClient() *fakeauth.FakeSubjectAccessReviews {
+	f := &k8stesting.Fake{}
+	f.AddReactor("create", "subjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &authv1.SubjectAccessReview{Status: authv1.SubjectAccessReviewStatus{Allowed: true}}, nil
+	})
+	return &fakeauth.FakeSubjectAccessReviews{Fake: &fakeauth.FakeAuthorizationV1{Fake: f}}
+}
@@ -0,0 +1,172 @@
+package offline
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const manifests = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: databases.example.com
+spec:
+  group: example.com
+  names:
+    kind: Database
+    plural: databases
+  scope: Namespaced
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+---
+apiVersion: example.com/v1alpha1
+kind: Database
+metadata:
+  name: db
+  namespace: ns1
+  annotations:
+    service.binding/host: path={.spec.host}
+    service.binding/password: path={.spec.secret},objectType=Secret,sourceKey=password
+spec:
+  host: db.example.com
+  secret: db-credentials
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: db-credentials
+    namespace: ns1
+  data:
+    password: c2VjcmV0
+- apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: app
+    namespace: ns1
+  spec:
+    template:
+      spec:
+        containers:
+        - name: app
+          image: app
+`
+
+const specBinding = `
+apiVersion: servicebinding.io/v1beta1
+kind: ServiceBinding
+metadata:
+  name: sb
+  namespace: ns1
+spec:
+  type: postgresql
+  service:
+    apiVersion: example.com/v1alpha1
+    kind: Database
+    name: db
+  workload:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: app
+`
+
+const coreosBinding = `
+apiVersion: binding.operators.coreos.com/v1alpha1
+kind: ServiceBinding
+metadata:
+  name: sb
+  namespace: ns1
+spec:
+  services:
+  - group: example.com
+    version: v1alpha1
+    kind: Database
+    name: %s
+  application:
+    group: apps
+    version: v1
+    resource: deployments
+    name: app
+`
+
+var _ = Describe("Offline cluster", func() {
+
+	decode := func(docs ...string) []*unstructured.Unstructured {
+		objs, err := Decode(strings.NewReader(strings.Join(docs, "\n---\n")))
+		Expect(err).NotTo(HaveOccurred())
+		return objs
+	}
+
+	bind := func(docs ...string) []*Result {
+		cluster, err := NewCluster(decode(docs...))
+		Expect(err).NotTo(HaveOccurred())
+		results, err := cluster.Bind()
+		Expect(err).NotTo(HaveOccurred())
+		return results
+	}
+
+	It("should decode documents and flatten lists", func() {
+		objs := decode(manifests, specBinding)
+		var kinds []string
+		for _, obj := range objs {
+			kinds = append(kinds, obj.GetKind())
+		}
+		Expect(kinds).To(Equal([]string{"CustomResourceDefinition", "Database", "Secret", "Deployment", "ServiceBinding"}))
+	})
+
+	It("should bind workload to service declared by spec service binding", func() {
+		results := bind(manifests, specBinding)
+
+		Expect(results).To(HaveLen(1))
+		r := results[0]
+		Expect(r.Err).NotTo(HaveOccurred())
+		Expect(r.Ready).To(BeTrue())
+		Expect(r.Secret).NotTo(BeNil())
+		secretName, _, _ := unstructured.NestedString(r.Binding.Object, "status", "binding", "name")
+		Expect(r.Secret.GetName()).To(Equal(secretName))
+		data, _, _ := unstructured.NestedStringMap(r.Secret.Object, "data")
+		Expect(data).To(Equal(map[string]string{
+			"host":     "ZGIuZXhhbXBsZS5jb20=",
+			"password": "c2VjcmV0",
+			"type":     "cG9zdGdyZXNxbA==",
+		}))
+		Expect(r.Workloads).To(HaveLen(1))
+		Expect(r.Workloads[0].GetKind()).To(Equal("Deployment"))
+		volumes, _, _ := unstructured.NestedSlice(r.Workloads[0].Object, "spec", "template", "spec", "volumes")
+		Expect(volumes).To(ConsistOf(map[string]interface{}{
+			"name":   "sb",
+			"secret": map[string]interface{}{"secretName": secretName},
+		}))
+	})
+
+	It("should bind workload to service declared by coreos service binding", func() {
+		results := bind(manifests, fmt.Sprintf(coreosBinding, "db"))
+
+		Expect(results).To(HaveLen(1))
+		r := results[0]
+		Expect(r.Err).NotTo(HaveOccurred())
+		Expect(r.Ready).To(BeTrue())
+		bindAsFiles, _, _ := unstructured.NestedBool(r.Binding.Object, "spec", "bindAsFiles")
+		Expect(bindAsFiles).To(BeTrue())
+		Expect(r.Secret).NotTo(BeNil())
+		Expect(r.Workloads).To(HaveLen(1))
+	})
+
+	It("should report service binding which cannot be bound", func() {
+		results := bind(manifests, fmt.Sprintf(coreosBinding, "missing"))
+
+		Expect(results).To(HaveLen(1))
+		r := results[0]
+		Expect(r.Ready).To(BeFalse())
+		Expect(r.Secret).To(BeNil())
+		Expect(r.Workloads).To(BeEmpty())
+	})
+})
@@ -0,0 +1,367 @@
+package cache
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/metrics"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/informers"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+)
+
+var _ pipeline.ResourceCache = &Cache{}
+var _ pipeline.OwnerIndex = &cachedClient{}
+
+// ownerIndex indexes cached resources by the UIDs of their owners
+const ownerIndex = "owner"
+
+// SyncTimeout is the maximum time to wait for the initial listing of resources of a given type,
+// resources not synced in time are read from the API server until their informer gets synced
+var SyncTimeout = 30 * time.Second
+
+// Cache serves reads of resources from informers, started lazily for each resource type on its first read.
+// Resources that cannot be listed and watched by the operator are read from the API server.
+type Cache struct {
+	lock sync.Mutex
+
+	log          logr.Logger
+	factory      dynamicinformer.DynamicSharedInformerFactory
+	accessReview authv1.SelfSubjectAccessReviewInterface
+
+	// closed when the cache is stopped, nil until it is started
+	stop <-chan struct{}
+
+	informers map[schema.GroupVersionResource]*informer
+}
+
+type informer struct {
+	lock sync.Mutex
+
+	// true once it is known whether resources of the given type can be cached
+	resolved bool
+
+	// nil if resources of the given type are not cached
+	informer informers.GenericInformer
+}
+
+// synced returns the informer if it has synced, or nil if resources are not cached or not synced yet
+func (i *informer) synced() informers.GenericInformer {
+	if i == nil || i.informer == nil || !i.informer.Informer().HasSynced() {
+		return nil
+	}
+	return i.informer
+}
+
+// New creates a cache using the given client to list and watch resources, and access reviews
+// to skip resources that cannot be watched
+func New(log logr.Logger, client dynamic.Interface, accessReview authv1.SelfSubjectAccessReviewInterface) *Cache {
+	return &Cache{
+		log:          log,
+		factory:      dynamicinformer.NewDynamicSharedInformerFactory(client, 0),
+		accessReview: accessReview,
+		informers:    make(map[schema.GroupVersionResource]*informer),
+	}
+}
+
+// Start enables reads from the cache until the given context is done
+func (c *Cache) Start(ctx context.Context) error {
+	c.lock.Lock()
+	c.stop = ctx.Done()
+	c.lock.Unlock()
+	<-ctx.Done()
+	c.factory.Shutdown()
+	return nil
+}
+
+// Client returns a client reading resources from the cache, other requests are sent with the given client
+func (c *Cache) Client(client dynamic.Interface) dynamic.Interface {
+	return &cachedClient{Interface: client, cache: c}
+}
+
+// lister returns the lister of resources of the given type, or nil if they are not cached
+func (c *Cache) lister(gvr schema.GroupVersionResource) toolscache.GenericLister {
+	if inf := c.informer(gvr).synced(); inf != nil {
+		return inf.Lister()
+	}
+	return nil
+}
+
+// indexer returns the indexer of resources of the given type, or nil if they are not cached
+func (c *Cache) indexer(gvr schema.GroupVersionResource) toolscache.Indexer {
+	if inf := c.informer(gvr).synced(); inf != nil {
+		return inf.Informer().GetIndexer()
+	}
+	return nil
+}
+
+func (c *Cache) informer(gvr schema.GroupVersionResource) *informer {
+	c.lock.Lock()
+	stop := c.stop
+	if stop == nil || isClosed(stop) {
+		c.lock.Unlock()
+		return nil
+	}
+	inf, ok := c.informers[gvr]
+	if !ok {
+		inf = &informer{}
+		c.informers[gvr] = inf
+	}
+	c.lock.Unlock()
+
+	inf.lock.Lock()
+	defer inf.lock.Unlock()
+	if !inf.resolved {
+		inf.resolved = c.startInformer(inf, gvr, stop)
+	}
+	return inf
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// startInformer starts the informer of resources of the given type if the operator is allowed to watch them,
+// returns false if access could not be reviewed, so that starting the informer is attempted again on next read
+func (c *Cache) startInformer(result *informer, gvr schema.GroupVersionResource, stop <-chan struct{}) bool {
+	log := c.log.WithValues("resource", gvr.GroupResource())
+	allowed, err := c.canWatch(gvr)
+	if err != nil {
+		log.Error(err, "Unable to review self subject access, resources are read from the API server")
+		return false
+	}
+	if !allowed {
+		log.Info("Not allowed to watch resources, they are read from the API server")
+		return true
+	}
+	inf := c.factory.ForResource(gvr)
+	if err := inf.Informer().AddIndexers(toolscache.Indexers{ownerIndex: ownerUIDs}); err != nil {
+		log.Error(err, "Unable to index resources by owner")
+		return true
+	}
+	c.factory.Start(stop)
+
+	ctx, cancel := context.WithTimeout(context.Background(), SyncTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	// the informer keeps running if it does not sync in time, resources are read from the cache once it has synced
+	result.informer = inf
+	if !toolscache.WaitForCacheSync(ctx.Done(), inf.Informer().HasSynced) {
+		log.Info("Resources not synced in time, they are read from the API server until synced")
+		return true
+	}
+	log.Info("Caching resources")
+	return true
+}
+
+func ownerUIDs(obj interface{}) ([]string, error) {
+	o, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, err
+	}
+	var uids []string
+	for _, ref := range o.GetOwnerReferences() {
+		uids = append(uids, string(ref.UID))
+	}
+	return uids, nil
+}
+
+func (c *Cache) canWatch(gvr schema.GroupVersionResource) (bool, error) {
+	for _, verb := range []string{"list", "watch"} {
+		review, err := c.accessReview.Create(context.Background(), &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     verb,
+					Group:    gvr.Group,
+					Version:  gvr.Version,
+					Resource: gvr.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		if !review.Status.Allowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type cachedClient struct {
+	dynamic.Interface
+	cache *Cache
+}
+
+func (c *cachedClient) Resource(gvr schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &namespaceableResource{
+		NamespaceableResourceInterface: c.Interface.Resource(gvr),
+		reader:                         reader{cache: c.cache, gvr: gvr},
+	}
+}
+
+// OwnedBy returns cached resources of the given type and namespace owned by the resource with the given UID
+func (c *cachedClient) OwnedBy(ctx context.Context, gvr schema.GroupVersionResource, namespace string, owner types.UID) ([]*unstructured.Unstructured, bool, error) {
+	indexer := c.cache.indexer(gvr)
+	if indexer == nil {
+		return nil, false, nil
+	}
+	reader{cache: c.cache, gvr: gvr}.observe(true)
+	objs, err := indexer.ByIndex(ownerIndex, string(owner))
+	if err != nil {
+		return nil, false, err
+	}
+	var result []*unstructured.Unstructured
+	for _, obj := range objs {
+		u := obj.(*unstructured.Unstructured)
+		if namespace == "" || u.GetNamespace() == namespace {
+			result = append(result, u.DeepCopy())
+		}
+	}
+	// indexers return resources in no particular order, owned resources are collected in a stable order
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+	return result, true, nil
+}
+
+type namespaceableResource struct {
+	dynamic.NamespaceableResourceInterface
+	reader reader
+}
+
+func (r *namespaceableResource) Namespace(ns string) dynamic.ResourceInterface {
+	return &resource{
+		ResourceInterface: r.NamespaceableResourceInterface.Namespace(ns),
+		reader:            reader{cache: r.reader.cache, gvr: r.reader.gvr, namespace: ns},
+	}
+}
+
+func (r *namespaceableResource) Get(ctx context.Context, name string, options metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return r.reader.get(ctx, r.NamespaceableResourceInterface, name, options, subresources...)
+}
+
+func (r *namespaceableResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return r.reader.list(ctx, r.NamespaceableResourceInterface, opts)
+}
+
+type resource struct {
+	dynamic.ResourceInterface
+	reader reader
+}
+
+func (r *resource) Get(ctx context.Context, name string, options metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return r.reader.get(ctx, r.ResourceInterface, name, options, subresources...)
+}
+
+func (r *resource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	return r.reader.list(ctx, r.ResourceInterface, opts)
+}
+
+// reader reads resources of the given type and namespace from the cache, falling back to the API server
+// for resources that are not cached and for requests that cannot be served from the cache
+type reader struct {
+	cache     *Cache
+	gvr       schema.GroupVersionResource
+	namespace string
+}
+
+func (r reader) get(ctx context.Context, client dynamic.ResourceInterface, name string, options metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	var lister toolscache.GenericLister
+	if len(subresources) == 0 && options.ResourceVersion == "" {
+		lister = r.cache.lister(r.gvr)
+	}
+	if lister == nil {
+		r.observe(false)
+		return client.Get(ctx, name, options, subresources...)
+	}
+	r.observe(true)
+	var obj runtime.Object
+	var err error
+	if r.namespace == "" {
+		obj, err = lister.Get(name)
+	} else {
+		obj, err = lister.ByNamespace(r.namespace).Get(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// cached objects are shared, callers get their own copy to modify
+	return obj.(*unstructured.Unstructured).DeepCopy(), nil
+}
+
+func (r reader) list(ctx context.Context, client dynamic.ResourceInterface, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	var lister toolscache.GenericLister
+	var selector labels.Selector
+	if opts.FieldSelector == "" && opts.ResourceVersion == "" && opts.Limit == 0 && opts.Continue == "" {
+		var err error
+		if selector, err = labels.Parse(opts.LabelSelector); err == nil {
+			lister = r.cache.lister(r.gvr)
+		}
+	}
+	if lister == nil {
+		r.observe(false)
+		return client.List(ctx, opts)
+	}
+	r.observe(true)
+	var objs []runtime.Object
+	var err error
+	if r.namespace == "" {
+		objs, err = lister.List(selector)
+	} else {
+		objs, err = lister.ByNamespace(r.namespace).List(selector)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := &unstructured.UnstructuredList{}
+	for _, obj := range objs {
+		result.Items = append(result.Items, *obj.(*unstructured.Unstructured).DeepCopy())
+	}
+	// listers return resources in no particular order, unlike the API server
+	sort.Slice(result.Items, func(i, j int) bool {
+		return less(&result.Items[i], &result.Items[j])
+	})
+	return result, nil
+}
+
+// less orders resources by namespace and name
+func less(a *unstructured.Unstructured, b *unstructured.Unstructured) bool {
+	if a.GetNamespace() != b.GetNamespace() {
+		return a.GetNamespace() < b.GetNamespace()
+	}
+	return a.GetName() < b.GetName()
+}
+
+func (r reader) observe(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	metrics.CacheReads.WithLabelValues(r.gvr.GroupResource().String(), result).Inc()
+}
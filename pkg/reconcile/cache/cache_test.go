@@ -0,0 +1,326 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/metrics"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/fake"
+	fakeauth "k8s.io/client-go/kubernetes/typed/authorization/v1/fake"
+	"k8s.io/client-go/testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache", func() {
+
+	var (
+		client  *fake.FakeDynamicClient
+		cache   *Cache
+		cached  dynamic.Interface
+		allowed map[string]bool
+		cancel  context.CancelFunc
+
+		// error returned by access reviews, if not nil
+		reviewErr error
+
+		secretsGVR = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+		foosGVR    = schema.GroupVersionResource{Group: "foo", Version: "v1", Resource: "foos"}
+	)
+
+	object := func(gvk schema.GroupVersionKind, namespace string, name string, lbls map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		u.SetLabels(lbls)
+		return u
+	}
+
+	// number of requests of the given verb sent to the API server
+	requests := func(verb string) int {
+		n := 0
+		for _, a := range client.Actions() {
+			if a.GetVerb() == verb {
+				n++
+			}
+		}
+		return n
+	}
+
+	reads := func(gvr schema.GroupVersionResource, result string) float64 {
+		return testutil.ToFloat64(metrics.CacheReads.WithLabelValues(gvr.GroupResource().String(), result))
+	}
+
+	start := func() {
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		go func() {
+			defer GinkgoRecover()
+			Expect(cache.Start(ctx)).To(Succeed())
+		}()
+		Eventually(func() bool {
+			cache.lock.Lock()
+			defer cache.lock.Unlock()
+			return cache.stop != nil
+		}).Should(BeTrue())
+	}
+
+	BeforeEach(func() {
+		allowed = map[string]bool{"secrets": true, "foos": true}
+		reviewErr = nil
+		authClient := &fakeauth.FakeAuthorizationV1{Fake: &testing.Fake{}}
+		authClient.AddReactor("create", "selfsubjectaccessreviews", func(action testing.Action) (bool, runtime.Object, error) {
+			if reviewErr != nil {
+				return true, nil, reviewErr
+			}
+			review := action.(testing.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+			review.Status.Allowed = allowed[review.Spec.ResourceAttributes.Resource]
+			return true, review, nil
+		})
+		client = fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{secretsGVR: "SecretList", foosGVR: "FooList"},
+			object(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, "ns1", "s1", map[string]string{"app": "a"}),
+			object(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, "ns1", "s2", map[string]string{"app": "b"}),
+			object(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, "ns2", "s1", map[string]string{"app": "a"}),
+			object(schema.GroupVersionKind{Group: "foo", Version: "v1", Kind: "Foo"}, "", "f1", nil),
+		)
+		cache = New(logr.Discard(), client, authClient.SelfSubjectAccessReviews())
+		cached = cache.Client(client)
+		cancel = func() {}
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	It("should read from the API server until started", func() {
+		misses := reads(secretsGVR, "miss")
+
+		u, err := cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.GetName()).To(Equal("s1"))
+		Expect(requests("get")).To(Equal(1))
+		Expect(reads(secretsGVR, "miss")).To(Equal(misses + 1))
+	})
+
+	It("should read resources from the cache once started", func() {
+		start()
+		hits := reads(secretsGVR, "hit")
+
+		u, err := cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.GetNamespace()).To(Equal("ns1"))
+		Expect(u.GetName()).To(Equal("s1"))
+
+		_, err = cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s3", metav1.GetOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		f, err := cached.Resource(foosGVR).Get(context.Background(), "f1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(f.GetName()).To(Equal("f1"))
+
+		Expect(requests("get")).To(Equal(0))
+		Expect(requests("list")).To(Equal(2))
+		Expect(reads(secretsGVR, "hit")).To(Equal(hits + 2))
+	})
+
+	It("should list resources matching label selector from the cache", func() {
+		start()
+
+		list, err := cached.Resource(secretsGVR).Namespace("ns1").List(context.Background(), metav1.ListOptions{LabelSelector: "app=a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(HaveLen(1))
+		Expect(list.Items[0].GetName()).To(Equal("s1"))
+
+		list, err = cached.Resource(secretsGVR).Namespace("ns1").List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.Items).To(HaveLen(2))
+
+		// the informer lists all secrets once
+		Expect(requests("list")).To(Equal(1))
+	})
+
+	It("should list cached resources ordered by namespace and name", func() {
+		for _, ref := range [][2]string{{"ns2", "s0"}, {"ns1", "s0"}, {"ns1", "s3"}} {
+			_, err := client.Resource(secretsGVR).Namespace(ref[0]).Create(context.Background(),
+				object(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, ref[0], ref[1], nil), metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		start()
+
+		list, err := cached.Resource(secretsGVR).List(context.Background(), metav1.ListOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, u := range list.Items {
+			names = append(names, u.GetNamespace()+"/"+u.GetName())
+		}
+		Expect(names).To(Equal([]string{"ns1/s0", "ns1/s1", "ns1/s2", "ns1/s3", "ns2/s0", "ns2/s1"}))
+	})
+
+	It("should read resources from the cache once their informer has synced after the sync timeout", func() {
+		timeout := SyncTimeout
+		defer func() { SyncTimeout = timeout }()
+		SyncTimeout = 10 * time.Millisecond
+		var failing atomic.Bool
+		failing.Store(true)
+		client.PrependReactor("list", "secrets", func(action testing.Action) (bool, runtime.Object, error) {
+			if failing.Load() {
+				return true, nil, errors.NewServiceUnavailable("not yet")
+			}
+			return false, nil, nil
+		})
+		start()
+		misses := reads(secretsGVR, "miss")
+
+		_, err := cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reads(secretsGVR, "miss")).To(Equal(misses + 1))
+
+		failing.Store(false)
+		hits := reads(secretsGVR, "hit")
+		Eventually(func() float64 {
+			_, err := cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			return reads(secretsGVR, "hit")
+		}, 10*time.Second).Should(BeNumerically(">", hits))
+	})
+
+	It("should return copies of cached resources", func() {
+		start()
+
+		u, err := cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		u.SetLabels(map[string]string{"app": "c"})
+
+		u, err = cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(u.GetLabels()).To(Equal(map[string]string{"app": "a"}))
+	})
+
+	It("should reflect changes of cached resources", func() {
+		start()
+
+		_, err := cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = cached.Resource(secretsGVR).Namespace("ns1").Create(context.Background(),
+			object(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, "ns1", "s3", nil), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(requests("create")).To(Equal(1))
+
+		Eventually(func() error {
+			_, err := cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s3", metav1.GetOptions{})
+			return err
+		}).Should(Succeed())
+	})
+
+	It("should look up cached resources by owner", func() {
+		for _, ns := range []string{"ns1", "ns2"} {
+			owned := object(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, ns, "owned", nil)
+			owned.SetOwnerReferences([]metav1.OwnerReference{{UID: "owner1"}})
+			_, err := client.Resource(secretsGVR).Namespace(ns).Create(context.Background(), owned, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		index := cached.(pipeline.OwnerIndex)
+
+		_, indexed, err := index.OwnedBy(context.Background(), secretsGVR, "ns1", "owner1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indexed).To(BeFalse())
+
+		start()
+
+		result, indexed, err := index.OwnedBy(context.Background(), secretsGVR, "ns1", "owner1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indexed).To(BeTrue())
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].GetNamespace()).To(Equal("ns1"))
+		Expect(result[0].GetName()).To(Equal("owned"))
+
+		result, indexed, err = index.OwnedBy(context.Background(), secretsGVR, "ns1", "owner2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indexed).To(BeTrue())
+		Expect(result).To(BeEmpty())
+
+		allowed["foos"] = false
+		_, indexed, err = index.OwnedBy(context.Background(), foosGVR, "", "owner1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indexed).To(BeFalse())
+	})
+
+	It("should return cached resources owned by the same owner ordered by namespace and name", func() {
+		for _, ref := range [][2]string{{"ns2", "c"}, {"ns1", "b"}, {"ns2", "a"}, {"ns1", "c"}, {"ns1", "a"}} {
+			owned := object(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, ref[0], ref[1], nil)
+			owned.SetOwnerReferences([]metav1.OwnerReference{{UID: "owner1"}})
+			_, err := client.Resource(secretsGVR).Namespace(ref[0]).Create(context.Background(), owned, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		start()
+
+		result, indexed, err := cached.(pipeline.OwnerIndex).OwnedBy(context.Background(), secretsGVR, "", "owner1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(indexed).To(BeTrue())
+		var names []string
+		for _, u := range result {
+			names = append(names, u.GetNamespace()+"/"+u.GetName())
+		}
+		Expect(names).To(Equal([]string{"ns1/a", "ns1/b", "ns1/c", "ns2/a", "ns2/c"}))
+	})
+
+	It("should read from the API server resources that cannot be watched", func() {
+		allowed["secrets"] = false
+		start()
+		misses := reads(secretsGVR, "miss")
+
+		_, err := cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s2", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(requests("get")).To(Equal(2))
+		Expect(requests("watch")).To(Equal(0))
+		Expect(reads(secretsGVR, "miss")).To(Equal(misses + 2))
+	})
+
+	It("should cache resources whose access could not be reviewed once it can be", func() {
+		reviewErr = errors.NewServiceUnavailable("not yet")
+		start()
+		misses := reads(secretsGVR, "miss")
+		hits := reads(secretsGVR, "hit")
+
+		_, err := cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reads(secretsGVR, "miss")).To(Equal(misses + 1))
+
+		reviewErr = nil
+		_, err = cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reads(secretsGVR, "hit")).To(Equal(hits + 1))
+		Expect(requests("get")).To(Equal(1))
+	})
+
+	It("should read from the API server requests that cannot be served from the cache", func() {
+		start()
+
+		_, err := cached.Resource(secretsGVR).Namespace("ns1").List(context.Background(), metav1.ListOptions{FieldSelector: "metadata.name=s1"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cached.Resource(secretsGVR).Namespace("ns1").Get(context.Background(), "s1", metav1.GetOptions{ResourceVersion: "0"})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(requests("get")).To(Equal(1))
+		Expect(requests("list")).To(Equal(1))
+		Expect(requests("watch")).To(Equal(0))
+	})
+})
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const namespace = "service_binding_operator"
+
+var (
+	// HandlerDuration observes the time spent in each handler of the binding pipeline
+	HandlerDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "handler_duration_seconds",
+		Help:      "Time spent processing bindings per pipeline handler.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{"handler"})
+
+	// Retries counts binding processings ending with a retry request
+	Retries = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "retries_total",
+		Help:      "Number of binding processings requesting to be retried.",
+	})
+
+	// Panics counts panics recovered while processing bindings
+	Panics = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "panics_total",
+		Help:      "Number of panics recovered while processing bindings.",
+	})
+
+	// Errors counts false conditions set on bindings by condition type and reason
+	Errors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "errors_total",
+		Help:      "Number of binding errors by condition type and reason.",
+	}, []string{"type", "reason"})
+
+	// SecretWrites counts binding secrets written by operation, i.e. create or update
+	SecretWrites = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "secret_writes_total",
+		Help:      "Number of binding secrets written by operation.",
+	}, []string{"operation"})
+
+	// CacheReads counts reads of resources by resource type, and whether they are served from the cache
+	CacheReads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "cache_reads_total",
+		Help:      "Number of resource reads by resource and result, hit if served from the cache, miss otherwise.",
+	}, []string{"resource", "result"})
+
+	// Bindings is the number of bindings by namespace and status of their Ready condition
+	Bindings = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "bindings",
+		Help:      "Number of bindings by namespace and status of their Ready condition.",
+	}, []string{"namespace", "status"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(HandlerDuration, Retries, Panics, Errors, SecretWrites, CacheReads, Bindings)
+}
+
+// BindingKey identifies a binding across the reconciled kinds
+type BindingKey struct {
+	Kind string
+	types.NamespacedName
+}
+
+var (
+	lock     sync.Mutex
+	statuses = make(map[BindingKey]metav1.ConditionStatus)
+)
+
+// ObserveBinding records the status of the Ready condition of the given binding, which is
+// counted in the Bindings gauge until the binding is forgotten.
+func ObserveBinding(key BindingKey, status metav1.ConditionStatus) {
+	if status == "" {
+		status = metav1.ConditionUnknown
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if previous, ok := statuses[key]; ok {
+		if previous == status {
+			return
+		}
+		Bindings.WithLabelValues(key.Namespace, string(previous)).Dec()
+	}
+	statuses[key] = status
+	Bindings.WithLabelValues(key.Namespace, string(status)).Inc()
+}
+
+// ForgetBinding removes the given binding from the Bindings gauge, e.g. once it is deleted
+func ForgetBinding(key BindingKey) {
+	lock.Lock()
+	defer lock.Unlock()
+	if previous, ok := statuses[key]; ok {
+		Bindings.WithLabelValues(key.Namespace, string(previous)).Dec()
+		delete(statuses, key)
+	}
+}
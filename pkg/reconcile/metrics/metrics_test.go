@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Bindings gauge", func() {
+
+	BeforeEach(func() {
+		Bindings.Reset()
+		statuses = make(map[BindingKey]metav1.ConditionStatus)
+	})
+
+	key := func(kind string, ns string, name string) BindingKey {
+		return BindingKey{Kind: kind, NamespacedName: types.NamespacedName{Namespace: ns, Name: name}}
+	}
+
+	count := func(ns string, status metav1.ConditionStatus) float64 {
+		return testutil.ToFloat64(Bindings.WithLabelValues(ns, string(status)))
+	}
+
+	It("should count bindings by namespace and ready status", func() {
+		ObserveBinding(key("a", "ns1", "b1"), metav1.ConditionTrue)
+		ObserveBinding(key("a", "ns1", "b2"), metav1.ConditionFalse)
+		ObserveBinding(key("b", "ns1", "b2"), metav1.ConditionFalse)
+		ObserveBinding(key("a", "ns2", "b1"), "")
+
+		Expect(count("ns1", metav1.ConditionTrue)).To(Equal(1.0))
+		Expect(count("ns1", metav1.ConditionFalse)).To(Equal(2.0))
+		Expect(count("ns2", metav1.ConditionUnknown)).To(Equal(1.0))
+	})
+
+	It("should move bindings between statuses when they change", func() {
+		ObserveBinding(key("a", "ns1", "b1"), metav1.ConditionFalse)
+		ObserveBinding(key("a", "ns1", "b1"), metav1.ConditionFalse)
+		Expect(count("ns1", metav1.ConditionFalse)).To(Equal(1.0))
+
+		ObserveBinding(key("a", "ns1", "b1"), metav1.ConditionTrue)
+		Expect(count("ns1", metav1.ConditionFalse)).To(Equal(0.0))
+		Expect(count("ns1", metav1.ConditionTrue)).To(Equal(1.0))
+	})
+
+	It("should not count forgotten bindings", func() {
+		ObserveBinding(key("a", "ns1", "b1"), metav1.ConditionTrue)
+		ForgetBinding(key("a", "ns1", "b1"))
+		ForgetBinding(key("a", "ns1", "b2"))
+
+		Expect(count("ns1", metav1.ConditionTrue)).To(Equal(0.0))
+		Expect(statuses).To(BeEmpty())
+	})
+})
@@ -1,19 +1,26 @@
 package pipeline
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"time"
 
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/apis"
 	"github.com/redhat-developer/service-binding-operator/pkg/binding"
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 )
 
-//go:generate mockgen -destination=mocks/mocks_pipeline.go -package=mocks . Context,Service,CRD,Application,ContextProvider,Handler
+//go:generate mockgen -destination=mocks/mocks_pipeline.go -package=mocks . Context,Service,CRD,Application,ContextProvider,Handler,EventRecorder
 
 // Reconciliation pipeline
 type Pipeline interface {
@@ -22,7 +29,8 @@ type Pipeline interface {
 	// Returns true if processing should be repeated
 	// and optional error if occurred
 	// important: even if error occurred it might not be needed to retry processing
-	Process(binding interface{}) (bool, time.Duration, error)
+	// the given context is propagated to client calls made while processing, e.g. for tracing
+	Process(ctx context.Context, binding interface{}) (bool, time.Duration, error)
 }
 
 // A container-like object.  EnvFrom is optional; all other fields are required.
@@ -35,9 +43,10 @@ type MetaContainer struct {
 }
 
 type MetaPodSpec struct {
-	Containers []MetaContainer
-	Volume     []string
-	Data       map[string]interface{}
+	Containers  []MetaContainer
+	Volume      []string
+	Annotations []string
+	Data        map[string]interface{}
 }
 
 // A pipeline stage
@@ -108,6 +117,12 @@ type Application interface {
 
 	// Use a different mapping
 	SetMapping(WorkloadMapping)
+
+	// Record a container binding data have been injected into, along with the path they are mounted at if bound as files
+	AddBoundContainer(name string, mountPath string)
+
+	// Record the error preventing binding data to be injected into the application
+	SetError(err error)
 }
 
 type CRDDescription olmv1alpha1.CRDDescription
@@ -118,6 +133,11 @@ type CRD interface {
 	// CRD resource
 	HasResource
 
+	// Optional OLM descriptor of the custom resource, published by the ClusterServiceVersion owning the CRD
+	// Returns nil if no such ClusterServiceVersion exists
+	// Error might be returned if occurred during the operation
+	Descriptor() (*CRDDescription, error)
+
 	Bindable
 }
 
@@ -192,6 +212,10 @@ type Context interface {
 	// Sets context condition
 	SetCondition(condition *metav1.Condition)
 
+	// Authorizes reading the given secret or config map from a namespace other than the namespace of the service
+	// referring to it, according to the cross-namespace policy; authorized references are recorded in the binding status
+	AuthorizeCrossNamespaceReference(ref apis.CrossNamespaceReference) error
+
 	kubernetes.ConfigMapReader
 	kubernetes.SecretReader
 
@@ -201,6 +225,21 @@ type Context interface {
 
 	// Is this service binding getting its workloads from a label selector?
 	HasLabelSelector() bool
+
+	// Returns the policy of restarting bound workloads when binding data change
+	RestartPolicy() apis.RestartPolicy
+
+	// Returns the policy of handling failures to bind some of the workloads
+	FailurePolicy() apis.FailurePolicy
+
+	// Returns hash of binding data, it changes whenever any binding item changes
+	BindingDataHash() string
+
+	// Returns recorder of events about the service binding and its workloads
+	EventRecorder() EventRecorder
+
+	// Sets the context of client calls made from now on, e.g. carrying the span of the current handler
+	SetRequestContext(ctx context.Context)
 }
 
 // Provides context for a given service binding
@@ -208,6 +247,52 @@ type ContextProvider interface {
 	Get(binding interface{}) (Context, error)
 }
 
+// Reference to a resource read while processing a service binding
+type ResourceReference struct {
+	GroupVersionResource schema.GroupVersionResource
+	Namespace            string
+	Name                 string
+
+	// If set, the reference points to all resources in the namespace matching the selector, e.g. workloads
+	// selected by a binding, and name is ignored
+	Selector labels.Selector
+}
+
+// Keeps track of resources service bindings depend on,
+// so that bindings could be reprocessed when any of them changes
+type ResourceTracker interface {
+
+	// Replaces the set of resources the given binding depends on
+	// nil resources stop tracking of the binding
+	// Returns resources whose changes cannot be watched
+	Track(binding types.NamespacedName, resources []ResourceReference) []ResourceReference
+}
+
+// Serves reads of resources from a cache, so that they do not hit the API server on every reconciliation
+type ResourceCache interface {
+
+	// Returns a client reading resources from the cache, other requests are sent with the given client
+	Client(client dynamic.Interface) dynamic.Interface
+}
+
+// Looks up resources by the UID of their owner, implemented by clients returned by resource caches
+type OwnerIndex interface {
+
+	// Returns resources of the given type and namespace owned by the resource with the given UID,
+	// false if resources of the given type are not indexed
+	OwnedBy(ctx context.Context, gvr schema.GroupVersionResource, namespace string, owner types.UID) ([]*unstructured.Unstructured, bool, error)
+}
+
+// Records Kubernetes events about the service binding being processed
+type EventRecorder interface {
+
+	// Record an event about the service binding, eventType being either corev1.EventTypeNormal or corev1.EventTypeWarning
+	Event(eventType string, reason string, message string)
+
+	// Record an event about the given workload, along with the matching event about the service binding
+	WorkloadEvent(app Application, eventType string, reason string, message string)
+}
+
 type HandlerFunc func(ctx Context)
 
 func (f HandlerFunc) Handle(ctx Context) {
@@ -222,6 +307,49 @@ type BindingItem struct {
 	Source Service
 }
 
+// ValueType classifies values of binding items by the way they are projected
+type ValueType int
+
+const (
+	// strings and scalars, projected as text
+	StringValue ValueType = iota
+	// binary values, projected byte-for-byte
+	BytesValue
+	// maps and slices, projected as JSON documents
+	StructuredValue
+)
+
+// Type returns the type of the item value
+func (i *BindingItem) Type() ValueType {
+	switch i.Value.(type) {
+	case []byte:
+		return BytesValue
+	case map[string]interface{}, []interface{}:
+		return StructuredValue
+	}
+	switch reflect.ValueOf(i.Value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return StructuredValue
+	}
+	return StringValue
+}
+
+// Bytes returns the representation of the item value written into binding secrets and projected files
+func (i *BindingItem) Bytes() []byte {
+	switch i.Type() {
+	case BytesValue:
+		return i.Value.([]byte)
+	case StructuredValue:
+		if b, err := json.Marshal(i.Value); err == nil {
+			return b
+		}
+	}
+	if s, ok := i.Value.(string); ok {
+		return []byte(s)
+	}
+	return []byte(fmt.Sprintf("%v", i.Value))
+}
+
 type EnvBinding struct {
 	Var  string
 	Name string
@@ -241,7 +369,17 @@ func (items *BindingItems) AsMap() map[string]string {
 	result := make(map[string]string)
 
 	for _, i := range *items {
-		result[i.Name] = fmt.Sprintf("%v", i.Value)
+		result[i.Name] = string(i.Bytes())
+	}
+	return result
+}
+
+// Returns map representation of given list of binding items, with values as written into binding secrets
+func (items *BindingItems) AsByteMap() map[string][]byte {
+	result := make(map[string][]byte)
+
+	for _, i := range *items {
+		result[i.Name] = i.Bytes()
 	}
 	return result
 }
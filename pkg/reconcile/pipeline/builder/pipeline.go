@@ -1,14 +1,23 @@
 package builder
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
 	"time"
 
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/metrics"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/handler/collect"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/handler/mapping"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/handler/naming"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/handler/project"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/tracing"
+	"go.opentelemetry.io/otel/codes"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	k8sretry "k8s.io/client-go/util/retry"
 )
 
 var _ pipeline.Pipeline = &impl{}
@@ -18,9 +27,33 @@ type impl struct {
 	handlers    []pipeline.Handler
 }
 
-func (i *impl) Process(binding interface{}) (retry bool, delay time.Duration, err error) {
+func (i *impl) Process(requestCtx context.Context, binding interface{}) (retry bool, delay time.Duration, err error) {
+	// each attempt starts from the binding as read, since the context persists only changes made to it
+	// by the attempt, e.g. the status of a binding modified by an attempt interrupted by a conflict
+	var original k8sruntime.Object
+	if obj, ok := binding.(k8sruntime.Object); ok {
+		original = obj.DeepCopyObject()
+	}
+	attempts := 0
+	// workloads modified since they were read are read again, and bindings are projected again into them
+	err = k8sretry.RetryOnConflict(k8sretry.DefaultRetry, func() error {
+		if attempts > 0 && original != nil {
+			reflect.ValueOf(binding).Elem().Set(reflect.ValueOf(original.DeepCopyObject()).Elem())
+		}
+		attempts++
+		retry, delay, err = i.process(requestCtx, binding)
+		return err
+	})
+	if retry {
+		metrics.Retries.Inc()
+	}
+	return retry, delay, err
+}
+
+func (i *impl) process(requestCtx context.Context, binding interface{}) (retry bool, delay time.Duration, err error) {
 	defer func() {
 		if perr := recover(); perr != nil {
+			metrics.Panics.Inc()
 			retry = true
 			err = fmt.Errorf("panic occurred: %v", perr)
 		}
@@ -31,19 +64,33 @@ func (i *impl) Process(binding interface{}) (retry bool, delay time.Duration, er
 	}
 	var status pipeline.FlowStatus
 	for _, h := range i.handlers {
-		invokeHandler(h, ctx)
+		invokeHandler(requestCtx, h, ctx)
 		status = ctx.FlowStatus()
 		if status.Stop {
 			break
 		}
 	}
-	err = ctx.Close()
+	err = closeContext(requestCtx, ctx)
 	if err != nil {
 		return true, time.Duration(0), err
 	}
+	// closing the context might request reprocessing, e.g. if changes of selected workloads cannot be watched
+	status = ctx.FlowStatus()
 	return status.Retry, status.Delay, status.Err
 }
 
+// closeContext persists the changes made by the handlers, e.g. into the workloads, within its own span
+func closeContext(requestCtx context.Context, ctx pipeline.Context) error {
+	spanCtx, span := tracing.Tracer().Start(requestCtx, "Close")
+	defer span.End()
+	ctx.SetRequestContext(spanCtx)
+	err := ctx.Close()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
 type builder struct {
 	ctxProvider pipeline.ContextProvider
 	handlers    []pipeline.Handler
@@ -70,15 +117,37 @@ func Builder() *builder {
 	return &builder{}
 }
 
-func invokeHandler(h pipeline.Handler, ctx pipeline.Context) {
+func invokeHandler(requestCtx context.Context, h pipeline.Handler, ctx pipeline.Context) {
+	name := handlerName(h)
+	spanCtx, span := tracing.Tracer().Start(requestCtx, name)
+	start := time.Now()
 	defer func() {
-		if err := recover(); err != nil {
-			ctx.RetryProcessing(fmt.Errorf("panic occurred: %v", err))
+		metrics.HandlerDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+		if perr := recover(); perr != nil {
+			metrics.Panics.Inc()
+			err := fmt.Errorf("panic occurred: %v", perr)
+			span.SetStatus(codes.Error, err.Error())
+			ctx.RetryProcessing(err)
 		}
+		span.End()
 	}()
+	// client calls made by the handler are recorded as children of its span
+	ctx.SetRequestContext(spanCtx)
 	h.Handle(ctx)
 }
 
+// handlerName returns the name of the function implementing the handler, e.g. collect.PreFlight
+func handlerName(h pipeline.Handler) string {
+	v := reflect.ValueOf(h)
+	if v.Kind() != reflect.Func {
+		return fmt.Sprintf("%T", h)
+	}
+	name := runtime.FuncForPC(v.Pointer()).Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	// handlers returned by factories, e.g. project.PreFlightCheck(), are named after them
+	return strings.TrimSuffix(name, ".func1")
+}
+
 var defaultFlow = []pipeline.Handler{
 	pipeline.HandlerFunc(project.Unbind),
 	pipeline.HandlerFunc(collect.PreFlight),
@@ -93,6 +162,7 @@ var defaultFlow = []pipeline.Handler{
 	pipeline.HandlerFunc(project.InjectSecretRef),
 	pipeline.HandlerFunc(project.BindingsAsEnv),
 	pipeline.HandlerFunc(project.BindingsAsFiles),
+	pipeline.HandlerFunc(project.RestartOnChange),
 	pipeline.HandlerFunc(project.PostFlightCheck),
 }
 
@@ -106,6 +176,7 @@ var specFlow = []pipeline.Handler{
 	pipeline.HandlerFunc(project.PreFlightCheck("type")),
 	pipeline.HandlerFunc(project.BindingsAsEnv),
 	pipeline.HandlerFunc(project.BindingsAsFiles),
+	pipeline.HandlerFunc(project.RestartOnChange),
 	pipeline.HandlerFunc(project.PostFlightCheck),
 }
 
@@ -2,6 +2,7 @@ package builder_test
 
 import (
 	c "context"
+	"fmt"
 	"reflect"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes"
+	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes/fakeapply"
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes/mocks"
 	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/builder"
@@ -23,7 +25,9 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -32,22 +36,27 @@ import (
 var _ = Describe("Default Pipeline", func() {
 
 	var (
-		mockCtrl *gomock.Controller
-	)
+		mockCtrl   *gomock.Controller
+		ns         = "ns1"
+		appName    = "app1"
+		appGVR     = schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
+		serviceGVR = schema.GroupVersionResource{Group: "services", Version: "v1", Resource: "databases"}
+		serviceGVK = serviceGVR.GroupVersion().WithKind("Database")
+		sb         *v1alpha1.ServiceBinding
+		client     *fake.FakeDynamicClient
+		typeLookup *mocks.MockK8STypeLookup
 
-	BeforeEach(func() {
-		mockCtrl = gomock.NewController(GinkgoT())
-	})
+		// resource of the workload referred by the binding
+		workloadGVR schema.GroupVersionResource
+	)
 
 	AfterEach(func() {
 		mockCtrl.Finish()
 	})
 
-	It("should bind service to app successfully", func() {
-		ns := "ns1"
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
 		serviceName := "s1"
-		serviceGVR := schema.GroupVersionResource{Group: "services", Version: "v1", Resource: "databases"}
-		serviceGVK := serviceGVR.GroupVersion().WithKind("Database")
 		serviceRef := v1alpha1.Service{
 			NamespacedRef: v1alpha1.NamespacedRef{
 				Ref: v1alpha1.Ref{
@@ -58,9 +67,7 @@ var _ = Describe("Default Pipeline", func() {
 				},
 			},
 		}
-		appGVR := schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}
 		appGVK := appGVR.GroupVersion().WithKind("Deployment")
-		appName := "app1"
 		appRef := v1alpha1.Application{
 			Ref: v1alpha1.Ref{
 				Group:    appGVR.Group,
@@ -69,7 +76,7 @@ var _ = Describe("Default Pipeline", func() {
 				Name:     appName,
 			},
 		}
-		sb := &v1alpha1.ServiceBinding{
+		sb = &v1alpha1.ServiceBinding{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "sb1",
 				Namespace: ns,
@@ -113,23 +120,27 @@ var _ = Describe("Default Pipeline", func() {
 		sbUnstructured, err := converter.ToUnstructured(sb)
 		Expect(err).NotTo(HaveOccurred())
 
-		client := client(service, appUnstructured, sbUnstructured)
+		client = fakeClient(service, appUnstructured, sbUnstructured)
 
-		typeLookup := mocks.NewMockK8STypeLookup(mockCtrl)
+		workloadGVR = appGVR
+		typeLookup = mocks.NewMockK8STypeLookup(mockCtrl)
 		typeLookup.EXPECT().ResourceForReferable(gomock.Any()).DoAndReturn(func(r kubernetes.Referable) (*schema.GroupVersionResource, error) {
-			if reflect.DeepEqual(r, &appRef) {
-				return &appGVR, nil
+			if reflect.DeepEqual(r, &sb.Spec.Application) {
+				return &workloadGVR, nil
 			} else {
 				return &serviceGVR, nil
 			}
 		}).MinTimes(1)
 		typeLookup.EXPECT().ResourceForKind(serviceGVK).Return(&serviceGVR, nil)
 
+	})
+
+	It("should bind service to app successfully", func() {
 		authClient := &fakeauth.FakeAuthorizationV1{}
 
 		p := builder.DefaultBuilder.WithContextProvider(context.Provider(client, authClient.SubjectAccessReviews(), typeLookup)).Build()
 
-		retry, delay, err := p.Process(sb)
+		retry, delay, err := p.Process(c.Background(), sb)
 		Expect(err).NotTo(HaveOccurred())
 		Expect(retry).To(BeFalse())
 		Expect(delay).To(Equal(time.Duration(0)))
@@ -153,9 +164,9 @@ var _ = Describe("Default Pipeline", func() {
 		secret := &corev1.Secret{}
 		err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, secret)
 		Expect(err).NotTo(HaveOccurred())
-		Expect(secret.StringData).To(Equal(map[string]string{
-			"DATABASE_BAR":  "val1",
-			"DATABASE_BAR2": "val2",
+		Expect(secret.Data).To(Equal(map[string][]byte{
+			"DATABASE_BAR":  []byte("val1"),
+			"DATABASE_BAR2": []byte("val2"),
 		}))
 
 		u, err = client.Resource(appGVR).Namespace(sb.Namespace).Get(c.Background(), appName, metav1.GetOptions{})
@@ -171,6 +182,207 @@ var _ = Describe("Default Pipeline", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(updatedApp.Spec.Template.Spec.Containers[0].EnvFrom[0].SecretRef.Name).To(Equal(updatedSB.Status.Secret))
 	})
+
+	It("should apply only fields set by the pipeline on app", func() {
+		authClient := &fakeauth.FakeAuthorizationV1{}
+
+		p := builder.DefaultBuilder.WithContextProvider(context.Provider(client, authClient.SubjectAccessReviews(), typeLookup)).Build()
+
+		retry, _, err := p.Process(c.Background(), sb)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retry).To(BeFalse())
+
+		var applied []*unstructured.Unstructured
+		for _, action := range client.Actions() {
+			if patch, ok := action.(k8stesting.PatchAction); ok && patch.GetPatchType() == types.ApplyPatchType {
+				u := &unstructured.Unstructured{}
+				Expect(u.UnmarshalJSON(patch.GetPatch())).To(Succeed())
+				applied = append(applied, u)
+			}
+		}
+		Expect(applied).To(HaveLen(1))
+		Expect(applied[0].GetName()).To(Equal(appName))
+		containers, _, err := unstructured.NestedSlice(applied[0].Object, "spec", "template", "spec", "containers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containers).To(Equal([]interface{}{
+			map[string]interface{}{
+				"name": "",
+				"envFrom": []interface{}{
+					map[string]interface{}{"secretRef": map[string]interface{}{"name": sb.Status.Secret}},
+				},
+			},
+		}))
+	})
+
+	It("should bind custom resource workload without dropping fields of its containers", func() {
+		workloadGVR = schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "services"}
+		workload := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "c1", "image": "foo"},
+						},
+					},
+				},
+			},
+		}}
+		workload.SetGroupVersionKind(workloadGVR.GroupVersion().WithKind("Service"))
+		workload.SetNamespace(ns)
+		workload.SetName("ksvc1")
+		_, err := client.Resource(workloadGVR).Namespace(ns).Create(c.Background(), workload, metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		sb.Spec.Application = v1alpha1.Application{
+			Ref: v1alpha1.Ref{
+				Group:    workloadGVR.Group,
+				Version:  workloadGVR.Version,
+				Resource: workloadGVR.Resource,
+				Name:     "ksvc1",
+			},
+		}
+		authClient := &fakeauth.FakeAuthorizationV1{}
+
+		p := builder.DefaultBuilder.WithContextProvider(context.Provider(client, authClient.SubjectAccessReviews(), typeLookup)).Build()
+
+		retry, _, err := p.Process(c.Background(), sb)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retry).To(BeFalse())
+
+		// lists of custom resources are atomic, hence applied as a whole
+		u, err := client.Resource(workloadGVR).Namespace(ns).Get(c.Background(), "ksvc1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		containers, _, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containers).To(Equal([]interface{}{
+			map[string]interface{}{
+				"name":  "c1",
+				"image": "foo",
+				"envFrom": []interface{}{
+					map[string]interface{}{"secretRef": map[string]interface{}{"name": sb.Status.Secret}},
+				},
+			},
+		}))
+
+		now := metav1.Now()
+		sb.DeletionTimestamp = &now
+		_, _, err = p.Process(c.Background(), sb)
+		Expect(err).NotTo(HaveOccurred())
+
+		u, err = client.Resource(workloadGVR).Namespace(ns).Get(c.Background(), "ksvc1", metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		containers, _, err = unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containers).To(HaveLen(1))
+		Expect(containers[0]).To(HaveKeyWithValue("image", "foo"))
+		Expect(containers[0].(map[string]interface{})["envFrom"]).To(BeEmpty())
+	})
+
+	It("should bind app modified while being processed", func() {
+		authClient := &fakeauth.FakeAuthorizationV1{}
+		conflicts := 0
+		client.PrependReactor("patch", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if conflicts > 0 {
+				return false, nil, nil
+			}
+			conflicts++
+			// another writer modifies the app after it has been read
+			obj, err := client.Tracker().Get(appGVR, ns, appName)
+			Expect(err).NotTo(HaveOccurred())
+			u := obj.(*unstructured.Unstructured)
+			Expect(unstructured.SetNestedField(u.Object, int64(3), "spec", "replicas")).To(Succeed())
+			u.SetResourceVersion("2")
+			Expect(client.Tracker().Update(appGVR, u, ns)).To(Succeed())
+			return true, nil, errors.NewConflict(appGVR.GroupResource(), appName, fmt.Errorf("the object has been modified"))
+		})
+		// the service is looked up again when the binding is processed again
+		typeLookup.EXPECT().ResourceForKind(serviceGVK).Return(&serviceGVR, nil)
+
+		p := builder.DefaultBuilder.WithContextProvider(context.Provider(client, authClient.SubjectAccessReviews(), typeLookup)).Build()
+
+		retry, _, err := p.Process(c.Background(), sb)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retry).To(BeFalse())
+		Expect(conflicts).To(Equal(1))
+
+		u, err := client.Resource(appGVR).Namespace(sb.Namespace).Get(c.Background(), appName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		app := &appsv1.Deployment{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, app)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*app.Spec.Replicas).To(BeEquivalentTo(3))
+		Expect(app.Spec.Template.Spec.Containers[0].EnvFrom[0].SecretRef.Name).To(Equal(sb.Status.Secret))
+
+		// status set by the attempt interrupted by the conflict is persisted as well
+		u, err = client.Resource(v1alpha1.GroupVersionResource).Namespace(sb.Namespace).Get(c.Background(), sb.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		updatedSB := v1alpha1.ServiceBinding{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &updatedSB)).To(Succeed())
+		Expect(updatedSB.Status.Secret).NotTo(BeEmpty())
+		Expect(updatedSB.Status.Secret).To(Equal(sb.Status.Secret))
+		Expect(existCondition(updatedSB.Status.Conditions, apis.BindingReady, metav1.ConditionTrue)).To(BeTrue())
+	})
+
+	It("should remove applied fields from app on unbinding", func() {
+		authClient := &fakeauth.FakeAuthorizationV1{}
+
+		p := builder.DefaultBuilder.WithContextProvider(context.Provider(client, authClient.SubjectAccessReviews(), typeLookup)).Build()
+
+		_, _, err := p.Process(c.Background(), sb)
+		Expect(err).NotTo(HaveOccurred())
+
+		now := metav1.Now()
+		sb.DeletionTimestamp = &now
+		_, _, err = p.Process(c.Background(), sb)
+		Expect(err).NotTo(HaveOccurred())
+
+		u, err := client.Resource(appGVR).Namespace(sb.Namespace).Get(c.Background(), appName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		app := &appsv1.Deployment{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, app)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(app.Spec.Template.Spec.Containers[0].EnvFrom).To(BeEmpty())
+		Expect(app.Spec.Template.Spec.Containers[0].Image).To(Equal("foo"))
+	})
+
+	It("should report binding without modifying app in dry-run mode", func() {
+		sb.SetAnnotations(map[string]string{apis.DryRunAnnotationKey: "true"})
+		authClient := &fakeauth.FakeAuthorizationV1{}
+
+		p := builder.DefaultBuilder.WithContextProvider(context.Provider(client, authClient.SubjectAccessReviews(), typeLookup)).Build()
+
+		retry, _, err := p.Process(c.Background(), sb)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retry).To(BeFalse())
+
+		u, err := client.Resource(v1alpha1.GroupVersionResource).Namespace(sb.Namespace).Get(c.Background(), sb.Name, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+
+		updatedSB := v1alpha1.ServiceBinding{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &updatedSB)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updatedSB.Status.Secret).To(BeEmpty())
+		Expect(existCondition(updatedSB.Status.Conditions, apis.BindingReady, metav1.ConditionFalse)).To(BeTrue())
+
+		dryRun := updatedSB.Status.DryRun
+		Expect(dryRun).NotTo(BeNil())
+		Expect(dryRun.Secret).NotTo(BeEmpty())
+		Expect(dryRun.BindingKeys).To(Equal([]string{"DATABASE_BAR", "DATABASE_BAR2"}))
+		Expect(dryRun.Workloads).To(HaveLen(1))
+		Expect(dryRun.Workloads[0].Name).To(Equal(appName))
+		Expect(dryRun.Workloads[0].Resource).To(Equal(appGVR.Resource))
+		Expect(dryRun.Workloads[0].Patch).To(ContainSubstring(`{"op":"add","path":"/spec/template/spec/containers/0/envFrom","value":[{"secretRef":{"name":"` + dryRun.Secret + `"}}]}`))
+		Expect(dryRun.Workloads[0].Patch).NotTo(ContainSubstring("val1"))
+
+		_, err = client.Resource(schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}).Namespace(sb.Namespace).Get(c.Background(), dryRun.Secret, metav1.GetOptions{})
+		Expect(errors.IsNotFound(err)).To(BeTrue())
+
+		u, err = client.Resource(appGVR).Namespace(sb.Namespace).Get(c.Background(), appName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		app := &appsv1.Deployment{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, app)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(app.Spec.Template.Spec.Containers[0].EnvFrom).To(BeEmpty())
+	})
 })
 
 func existCondition(conditions []metav1.Condition, conditionType string, status metav1.ConditionStatus) bool {
@@ -182,8 +394,10 @@ func existCondition(conditions []metav1.Condition, conditionType string, status
 	return false
 }
 
-func client(objs ...runtime.Object) *fake.FakeDynamicClient {
-	return fake.NewSimpleDynamicClient(runtime.NewScheme(), objs...)
+func fakeClient(objs ...runtime.Object) *fake.FakeDynamicClient {
+	client := fake.NewSimpleDynamicClient(runtime.NewScheme(), objs...)
+	fakeapply.AddReactor(client, context.FieldManager)
+	return client
 }
 
 func deployment(name string, containers []corev1.Container) *appsv1.Deployment {
@@ -1,15 +1,26 @@
 package builder_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/metrics"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/builder"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/mocks"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -27,6 +38,7 @@ var _ = Describe("Pipeline", func() {
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		ctx = mocks.NewMockContext(mockCtrl)
+		ctx.EXPECT().SetRequestContext(gomock.Any()).AnyTimes()
 	})
 
 	AfterEach(func() {
@@ -41,9 +53,9 @@ var _ = Describe("Pipeline", func() {
 		p := builder.Builder().WithContextProvider(&ctxProvider{ctx: ctx}).WithHandlers(h1, h2).Build()
 
 		ctx.EXPECT().Close().Return(nil)
-		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{}).Times(2)
+		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{}).Times(3)
 
-		retry, delay, err := p.Process(&v1alpha1.ServiceBinding{})
+		retry, delay, err := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(retry).To(BeFalse())
 		Expect(delay).To(Equal(time.Duration(0)))
@@ -60,12 +72,29 @@ var _ = Describe("Pipeline", func() {
 		ctx.EXPECT().Close().Return(err)
 		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{}).Times(2)
 
-		retry, delay, err := p.Process(&v1alpha1.ServiceBinding{})
+		retry, delay, err := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
 		Expect(err).To(Equal(err))
 		Expect(retry).To(BeTrue())
 		Expect(delay).To(Equal(time.Duration(0)))
 	})
 
+	It("should process binding again if workload has been modified meanwhile", func() {
+		h1 := defHandler()
+		h1.EXPECT().Handle(ctx).Times(2)
+		p := builder.Builder().WithContextProvider(&ctxProvider{ctx: ctx}).WithHandlers(h1).Build()
+
+		gomock.InOrder(
+			ctx.EXPECT().Close().Return(k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, "app", errors.New("foo"))),
+			ctx.EXPECT().Close().Return(nil),
+		)
+		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{}).Times(3)
+
+		retry, delay, err := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retry).To(BeFalse())
+		Expect(delay).To(Equal(time.Duration(0)))
+	})
+
 	It("should stop processing if retry requested and propagate error back to caller", func() {
 		err := errors.New("foo")
 
@@ -80,9 +109,9 @@ var _ = Describe("Pipeline", func() {
 		ctx.EXPECT().RetryProcessing(err)
 		ctx.EXPECT().Close().Return(nil)
 		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{})
-		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{Retry: true, Stop: true, Err: err})
+		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{Retry: true, Stop: true, Err: err}).Times(2)
 
-		retry, delay, err := p.Process(&v1alpha1.ServiceBinding{})
+		retry, delay, err := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
 		Expect(err).To(Equal(err))
 		Expect(retry).To(BeTrue())
 		Expect(delay).To(Equal(time.Duration(0)))
@@ -102,12 +131,79 @@ var _ = Describe("Pipeline", func() {
 		ctx.EXPECT().RetryProcessing(err)
 		ctx.EXPECT().Close().Return(nil)
 		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{})
-		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{Retry: true, Stop: true, Err: err})
+		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{Retry: true, Stop: true, Err: err}).Times(2)
+
+		panics := testutil.ToFloat64(metrics.Panics)
+		retries := testutil.ToFloat64(metrics.Retries)
 
-		retry, delay, rerr := p.Process(&v1alpha1.ServiceBinding{})
+		retry, delay, rerr := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
 		Expect(rerr).To(Equal(err))
 		Expect(retry).To(BeTrue())
 		Expect(delay).To(Equal(time.Duration(0)))
+		Expect(testutil.ToFloat64(metrics.Panics)).To(Equal(panics + 1))
+		Expect(testutil.ToFloat64(metrics.Retries)).To(Equal(retries + 1))
+	})
+
+	It("should trace processing within the span of the request", func() {
+		spans := tracetest.NewSpanRecorder()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+		defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+		tracingCtx := mocks.NewMockContext(mockCtrl)
+		var handlerSpan trace.SpanContext
+		h1 := defHandler()
+		h1.EXPECT().Handle(tracingCtx)
+		p := builder.Builder().WithContextProvider(&ctxProvider{ctx: tracingCtx}).WithHandlers(pipeline.HandlerFunc(noopHandler), h1).Build()
+
+		gomock.InOrder(
+			tracingCtx.EXPECT().SetRequestContext(gomock.Any()),
+			tracingCtx.EXPECT().SetRequestContext(gomock.Any()).Do(func(c context.Context) {
+				handlerSpan = trace.SpanContextFromContext(c)
+			}),
+			tracingCtx.EXPECT().SetRequestContext(gomock.Any()),
+		)
+		tracingCtx.EXPECT().Close().Return(nil)
+		tracingCtx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{}).Times(3)
+
+		requestCtx, reconcileSpan := otel.Tracer("test").Start(context.Background(), "Reconcile")
+		_, _, err := p.Process(requestCtx, &v1alpha1.ServiceBinding{})
+		reconcileSpan.End()
+		Expect(err).NotTo(HaveOccurred())
+
+		ended := spans.Ended()
+		var names []string
+		for _, s := range ended {
+			names = append(names, s.Name())
+			if s.Name() != "Reconcile" {
+				Expect(s.Parent().SpanID()).To(Equal(reconcileSpan.SpanContext().SpanID()))
+			}
+			if s.Name() == "*mocks.MockHandler" {
+				Expect(s.SpanContext().SpanID()).To(Equal(handlerSpan.SpanID()))
+			}
+		}
+		Expect(names).To(Equal([]string{"builder_test.noopHandler", "*mocks.MockHandler", "Close", "Reconcile"}))
+	})
+
+	It("should observe processing duration of each handler", func() {
+		h1 := defHandler()
+		h1.EXPECT().Handle(ctx)
+		p := builder.Builder().WithContextProvider(&ctxProvider{ctx: ctx}).WithHandlers(h1, pipeline.HandlerFunc(noopHandler)).Build()
+
+		ctx.EXPECT().Close().Return(nil)
+		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{}).Times(3)
+
+		samples := func(handler string) uint64 {
+			m := &dto.Metric{}
+			Expect(metrics.HandlerDuration.WithLabelValues(handler).(prometheus.Histogram).Write(m)).To(Succeed())
+			return m.GetHistogram().GetSampleCount()
+		}
+		mockSamples := samples("*mocks.MockHandler")
+		funcSamples := samples("builder_test.noopHandler")
+
+		_, _, err := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(samples("*mocks.MockHandler")).To(Equal(mockSamples + 1))
+		Expect(samples("builder_test.noopHandler")).To(Equal(funcSamples + 1))
 	})
 
 	It("should stop without retry and error and propagate that back to caller", func() {
@@ -122,9 +218,9 @@ var _ = Describe("Pipeline", func() {
 		ctx.EXPECT().StopProcessing()
 		ctx.EXPECT().Close().Return(nil)
 		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{})
-		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{Retry: false, Stop: true, Err: nil})
+		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{Retry: false, Stop: true, Err: nil}).Times(2)
 
-		retry, delay, err := p.Process(&v1alpha1.ServiceBinding{})
+		retry, delay, err := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
 		Expect(err).NotTo(HaveOccurred())
 		Expect(retry).To(BeFalse())
 		Expect(delay).To(Equal(time.Duration(0)))
@@ -141,7 +237,7 @@ var _ = Describe("Pipeline", func() {
 		ctx.EXPECT().Close().Return(err)
 		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{}).Times(2)
 
-		retry, delay, err := p.Process(&v1alpha1.ServiceBinding{})
+		retry, delay, err := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
 		Expect(err).To(Equal(err))
 		Expect(retry).To(BeTrue())
 		Expect(delay).To(Equal(time.Duration(0)))
@@ -156,7 +252,7 @@ var _ = Describe("Pipeline", func() {
 		ctx.EXPECT().Close().DoAndReturn(func() { panic("foo") })
 		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{}).Times(1)
 
-		retry, delay, perr := p.Process(&v1alpha1.ServiceBinding{})
+		retry, delay, perr := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
 		Expect(perr).To(Equal(err))
 		Expect(retry).To(BeTrue())
 		Expect(delay).To(Equal(time.Duration(0)))
@@ -169,7 +265,7 @@ var _ = Describe("Pipeline", func() {
 		provider.EXPECT().Get(&v1alpha1.ServiceBinding{}).DoAndReturn(func(b interface{}) { panic("foo") })
 		p := builder.Builder().WithContextProvider(provider).WithHandlers(h1).Build()
 
-		retry, delay, perr := p.Process(&v1alpha1.ServiceBinding{})
+		retry, delay, perr := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
 		Expect(perr).To(Equal(err))
 		Expect(retry).To(BeTrue())
 		Expect(delay).To(Equal(time.Duration(0)))
@@ -190,13 +286,32 @@ var _ = Describe("Pipeline", func() {
 		ctx.EXPECT().RetryProcessing(err)
 		ctx.EXPECT().Close().Return(nil)
 		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{})
-		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{Retry: true, Stop: true, Err: err, Delay: delay})
+		ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{Retry: true, Stop: true, Err: err, Delay: delay}).Times(2)
 
-		retry, rdelay, err := p.Process(&v1alpha1.ServiceBinding{})
+		retry, rdelay, err := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
 		Expect(err).To(Equal(err))
 		Expect(retry).To(BeTrue())
 		Expect(rdelay).To(Equal(delay))
 	})
+
+	It("should retry processing with a delay if closing the context requests it", func() {
+		delay := time.Minute
+
+		h1 := defHandler()
+		h1.EXPECT().Handle(ctx)
+		p := builder.Builder().WithContextProvider(&ctxProvider{ctx: ctx}).WithHandlers(h1).Build()
+
+		ctx.EXPECT().Close().Return(nil)
+		gomock.InOrder(
+			ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{}),
+			ctx.EXPECT().FlowStatus().Return(pipeline.FlowStatus{Retry: true, Delay: delay}),
+		)
+
+		retry, rdelay, err := p.Process(context.Background(), &v1alpha1.ServiceBinding{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(retry).To(BeTrue())
+		Expect(rdelay).To(Equal(delay))
+	})
 })
 
 type ctxProvider struct {
@@ -206,3 +321,6 @@ type ctxProvider struct {
 func (c *ctxProvider) Get(binding interface{}) (pipeline.Context, error) {
 	return c.ctx, nil
 }
+
+func noopHandler(ctx pipeline.Context) {
+}
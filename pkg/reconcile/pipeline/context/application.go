@@ -1,12 +1,20 @@
 package context
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/redhat-developer/service-binding-operator/apis"
 	"github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
 
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -21,6 +29,13 @@ type application struct {
 	bindingPath            *v1alpha1.BindingPath
 	bindableContainerNames sets.Set[string]
 	resourceMapping        pipeline.WorkloadMapping
+	boundContainers        []string
+	mountPath              string
+	err                    error
+	errReason              string
+	// bound is set once binding data have been persisted into the workload, whose generation is then recorded
+	bound           bool
+	boundGeneration int64
 }
 
 func (a *application) SecretPath() string {
@@ -41,6 +56,51 @@ func (a *application) IsUpdated() bool {
 	return !reflect.DeepEqual(a.persistedResource, a.resource)
 }
 
+// jsonPatch returns the JSON patch (RFC 6902) transforming the persisted resource into the updated one
+func (a *application) jsonPatch() (string, error) {
+	persisted, err := json.Marshal(a.persistedResource.Object)
+	if err != nil {
+		return "", err
+	}
+	updated, err := json.Marshal(a.Resource().Object)
+	if err != nil {
+		return "", err
+	}
+	ops, err := jsonpatch.CreatePatch(persisted, updated)
+	if err != nil {
+		return "", err
+	}
+	sortOperations(ops)
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return "", err
+	}
+	return string(patch), nil
+}
+
+// sortOperations orders patch operations by path, so that patches do not depend on map iteration order;
+// array elements are removed from the last one, so that the indices of remaining removals stay valid
+func sortOperations(ops []jsonpatch.Operation) {
+	sort.SliceStable(ops, func(i, j int) bool {
+		pi, pj := strings.Split(ops[i].Path, "/"), strings.Split(ops[j].Path, "/")
+		for k := 0; k < len(pi) && k < len(pj); k++ {
+			if pi[k] == pj[k] {
+				continue
+			}
+			ni, erri := strconv.Atoi(pi[k])
+			nj, errj := strconv.Atoi(pj[k])
+			if erri != nil || errj != nil {
+				return pi[k] < pj[k]
+			}
+			if ops[i].Operation == "remove" && ops[j].Operation == "remove" {
+				return ni > nj
+			}
+			return ni < nj
+		}
+		return len(pi) < len(pj)
+	})
+}
+
 func (a *application) BindablePods() (*pipeline.MetaPodSpec, error) {
 	var filteredContainers []pipeline.MetaContainer
 	for _, container := range a.resourceMapping.Containers {
@@ -88,9 +148,10 @@ func (a *application) BindablePods() (*pipeline.MetaPodSpec, error) {
 	}
 
 	containerTemplate := &pipeline.MetaPodSpec{
-		Volume:     a.resourceMapping.Volume,
-		Containers: filteredContainers,
-		Data:       a.resource.Object,
+		Volume:      a.resourceMapping.Volume,
+		Annotations: a.resourceMapping.Annotations,
+		Containers:  filteredContainers,
+		Data:        a.resource.Object,
 	}
 
 	return containerTemplate, nil
@@ -123,3 +184,61 @@ func (a *application) SetMapping(mapping pipeline.WorkloadMapping) {
 func (a *application) GroupVersionResource() schema.GroupVersionResource {
 	return *a.gvr
 }
+
+func (a *application) AddBoundContainer(name string, mountPath string) {
+	if a.mountPath == "" {
+		a.mountPath = mountPath
+	}
+	for _, c := range a.boundContainers {
+		if c == name {
+			return
+		}
+	}
+	a.boundContainers = append(a.boundContainers, name)
+}
+
+func (a *application) SetError(err error) {
+	a.setError("Error", err)
+}
+
+func (a *application) setError(reason string, err error) {
+	a.err = err
+	a.errReason = reason
+}
+
+// status reports the binding of the application, the given error having stopped processing before it got bound;
+// the transition time of the previous condition is kept if the condition status has not changed
+func (a *application) status(previous []apis.WorkloadStatus, stopErr error) apis.WorkloadStatus {
+	gvr := a.GroupVersionResource()
+	result := apis.WorkloadStatus{
+		Group:              gvr.Group,
+		Version:            gvr.Version,
+		Resource:           gvr.Resource,
+		Name:               a.persistedResource.GetName(),
+		ObservedGeneration: a.persistedResource.GetGeneration(),
+		Containers:         a.boundContainers,
+		MountPath:          a.mountPath,
+	}
+	var condition *metav1.Condition
+	switch {
+	case a.err != nil:
+		condition = apis.Conditions().NotInjectionReady().Reason(a.errReason).Msg(a.err.Error()).Build()
+	case a.bound:
+		condition = apis.Conditions().InjectionReady().Reason("ApplicationUpdated").Build()
+		result.ObservedGeneration = a.boundGeneration
+	default:
+		condition = apis.Conditions().UnknownInjectionReady().Reason("ProcessingError").Build()
+		if stopErr != nil {
+			condition.Message = stopErr.Error()
+		}
+	}
+	var conditions []metav1.Condition
+	for _, p := range previous {
+		if p.Group == result.Group && p.Resource == result.Resource && p.Name == result.Name {
+			conditions = append(conditions, p.Condition)
+		}
+	}
+	meta.SetStatusCondition(&conditions, *condition)
+	result.Condition = conditions[0]
+	return result
+}
@@ -76,6 +76,26 @@ var _ = Describe("Application", func() {
 		Expect(app.IsUpdated()).To(BeFalse())
 	})
 
+	It("should return JSON patch of modified resource", func() {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"items": []interface{}{"a", "b", "c", "d"},
+			},
+		}}
+		app := &application{persistedResource: u}
+		app.Resource().SetLabels(map[string]string{"foo": "bar", "bar": "baz"})
+		app.Resource().Object["spec"] = map[string]interface{}{
+			"items": []interface{}{"a", "b"},
+		}
+		patch, err := app.jsonPatch()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(patch).To(MatchJSON(`[
+			{"op":"add","path":"/metadata","value":{"labels":{"bar":"baz","foo":"bar"}}},
+			{"op":"remove","path":"/spec/items/3"},
+			{"op":"remove","path":"/spec/items/2"}
+		]`))
+	})
+
 	It("should return all containers if bindable position are not specified", func() {
 		c1 := corev1.Container{
 			Image: "foo",
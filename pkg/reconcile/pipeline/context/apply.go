@@ -0,0 +1,289 @@
+package context
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// FieldManager owns the workload fields applied by the operator
+const FieldManager = "service-binding-operator"
+
+// listKeyFunc returns the key identifying elements of the given list field, or an empty string
+// if the list is applied as a whole
+type listKeyFunc func(field string) string
+
+// fieldSet is a decoded managed fields set, as described by metav1.FieldsV1
+type fieldSet map[string]interface{}
+
+func (s fieldSet) child(key string) fieldSet {
+	c, ok := s[key].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return c
+}
+
+// element returns the fields owned in the given list element, and the fields identifying the element
+func (s fieldSet) element(e interface{}) (fieldSet, map[string]interface{}) {
+	for k := range s {
+		switch {
+		case strings.HasPrefix(k, "k:"):
+			var keys map[string]interface{}
+			m, ok := e.(map[string]interface{})
+			if !ok || json.Unmarshal([]byte(k[2:]), &keys) != nil {
+				continue
+			}
+			matched := true
+			for kf, kv := range keys {
+				if !jsonEqual(m[kf], kv) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				for kf := range keys {
+					keys[kf] = m[kf]
+				}
+				return s.child(k), keys
+			}
+		case strings.HasPrefix(k, "v:"):
+			var v interface{}
+			if json.Unmarshal([]byte(k[2:]), &v) == nil && jsonEqual(e, v) {
+				return s.child(k), nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// applyConfiguration returns the configuration turning the persisted resource into the updated one when applied
+// by the operator field manager: it holds the fields changed by the pipeline and the fields the operator already owns.
+// The returned flag is false if fields not owned by the operator have been removed, as applying cannot remove them.
+func (a *application) applyConfiguration() (*unstructured.Unstructured, bool, error) {
+	persisted, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&a.persistedResource.Object)
+	if err != nil {
+		return nil, false, err
+	}
+	updated, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&a.Resource().Object)
+	if err != nil {
+		return nil, false, err
+	}
+	var owned fieldSet
+	for _, mf := range a.persistedResource.GetManagedFields() {
+		if mf.Manager == FieldManager && mf.Operation == metav1.ManagedFieldsOperationApply && mf.Subresource == "" && mf.FieldsV1 != nil {
+			if err := json.Unmarshal(mf.FieldsV1.Raw, &owned); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	group := a.persistedResource.GroupVersionKind().Group
+	keys := func(field string) string {
+		return kubernetes.MapListKey(group, field)
+	}
+	config, complete := diffMap(persisted, updated, owned, keys)
+	result := &unstructured.Unstructured{Object: config}
+	result.SetAPIVersion(a.persistedResource.GetAPIVersion())
+	result.SetKind(a.persistedResource.GetKind())
+	result.SetName(a.persistedResource.GetName())
+	result.SetNamespace(a.persistedResource.GetNamespace())
+	// fail with a conflict if the resource has been modified since it was read, as changes are computed from it
+	result.SetResourceVersion(a.persistedResource.GetResourceVersion())
+	return result, complete, nil
+}
+
+func diffMap(persisted, updated map[string]interface{}, owned fieldSet, keys listKeyFunc) (map[string]interface{}, bool) {
+	result := make(map[string]interface{})
+	complete := true
+	for k, uv := range updated {
+		o := owned.child("f:" + k)
+		pv, found := persisted[k]
+		switch {
+		case uv == nil:
+			// null values are not applied, as they would remove fields
+			if !found || pv == nil {
+				continue
+			}
+			if o == nil {
+				complete = false
+			}
+		case !found || pv == nil:
+			result[k] = withoutNulls(uv)
+		case reflect.DeepEqual(pv, uv):
+			if v, ok := extract(uv, o); ok {
+				result[k] = v
+			}
+		default:
+			v, c := diffValue(pv, uv, o, keys(k), keys)
+			complete = complete && c
+			if v != nil {
+				result[k] = v
+			}
+		}
+	}
+	for k, pv := range persisted {
+		if _, found := updated[k]; !found && pv != nil && owned.child("f:"+k) == nil {
+			complete = false
+		}
+	}
+	return result, complete
+}
+
+// diffValue returns the configuration of the given value, lists identified by the given key are diffed by element,
+// other lists are sent as a whole as their elements cannot be merged
+func diffValue(persisted, updated interface{}, owned fieldSet, key string, keys listKeyFunc) (interface{}, bool) {
+	switch uv := updated.(type) {
+	case map[string]interface{}:
+		if pv, ok := persisted.(map[string]interface{}); ok {
+			r, c := diffMap(pv, uv, owned, keys)
+			if len(r) == 0 {
+				return nil, c
+			}
+			return r, c
+		}
+	case []interface{}:
+		if pv, ok := persisted.([]interface{}); ok && key != "" && keyed(pv, key) && keyed(uv, key) {
+			r, c := diffList(pv, uv, owned, key, keys)
+			if len(r) == 0 {
+				return nil, c
+			}
+			return r, c
+		}
+	}
+	return withoutNulls(updated), true
+}
+
+// withoutNulls returns the given value without null fields, which are not applied as they would remove fields
+func withoutNulls(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, fv := range v {
+			if fv != nil {
+				result[k] = withoutNulls(fv)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			result[i] = withoutNulls(e)
+		}
+		return result
+	}
+	return value
+}
+
+func diffList(persisted, updated []interface{}, owned fieldSet, key string, keys listKeyFunc) ([]interface{}, bool) {
+	var result []interface{}
+	complete := true
+	for _, e := range updated {
+		ue := e.(map[string]interface{})
+		pe := find(persisted, ue, key)
+		switch {
+		case pe == nil:
+			result = append(result, ue)
+		case reflect.DeepEqual(pe, ue):
+			if v, ok := extractElement(ue, owned); ok {
+				result = append(result, v)
+			}
+		default:
+			o, _ := owned.element(ue)
+			r, c := diffMap(pe, ue, o, keys)
+			complete = complete && c
+			if len(r) > 0 {
+				r[key] = ue[key]
+				result = append(result, r)
+			}
+		}
+	}
+	for _, e := range persisted {
+		if o, _ := owned.element(e); o == nil && find(updated, e.(map[string]interface{}), key) == nil {
+			complete = false
+		}
+	}
+	return result, complete
+}
+
+// extract returns the part of the given value owned as described by the given set
+func extract(value interface{}, owned fieldSet) (interface{}, bool) {
+	if owned == nil {
+		return nil, false
+	}
+	if len(owned) == 0 || (len(owned) == 1 && owned["."] != nil) {
+		return value, true
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		for k := range owned {
+			if !strings.HasPrefix(k, "f:") {
+				continue
+			}
+			if fv, found := v[k[2:]]; found {
+				if e, ok := extract(fv, owned.child(k)); ok {
+					result[k[2:]] = e
+				}
+			}
+		}
+		return result, len(result) > 0
+	case []interface{}:
+		var result []interface{}
+		for _, e := range v {
+			if x, ok := extractElement(e, owned); ok {
+				result = append(result, x)
+			}
+		}
+		return result, len(result) > 0
+	}
+	return value, true
+}
+
+// extractElement returns the owned part of the given list element, along with the fields identifying it
+func extractElement(e interface{}, owned fieldSet) (interface{}, bool) {
+	o, keys := owned.element(e)
+	x, ok := extract(e, o)
+	if !ok {
+		return nil, false
+	}
+	if m, isMap := x.(map[string]interface{}); isMap {
+		for kf, kv := range keys {
+			m[kf] = kv
+		}
+	}
+	return x, true
+}
+
+// keyed returns true if all elements of the list hold the given key
+func keyed(list []interface{}, key string) bool {
+	for _, e := range list {
+		m, ok := e.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := m[key].(string); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func find(list []interface{}, e map[string]interface{}, key string) map[string]interface{} {
+	for _, c := range list {
+		if m := c.(map[string]interface{}); m[key] == e[key] {
+			return m
+		}
+	}
+	return nil
+}
+
+func jsonEqual(a, b interface{}) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(ja) == string(jb)
+}
@@ -0,0 +1,168 @@
+package context
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ = Describe("Apply configuration", func() {
+
+	var app *application
+
+	BeforeEach(func() {
+		persisted := &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":            "app",
+				"namespace":       "ns1",
+				"resourceVersion": "5",
+			},
+			"spec": map[string]interface{}{
+				"replicas": int64(2),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name":  "c1",
+								"image": "foo",
+								"env": []interface{}{
+									map[string]interface{}{"name": "USER_VAR", "value": "v"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}}
+		app = &application{persistedResource: persisted}
+	})
+
+	containers := func(u *unstructured.Unstructured) []interface{} {
+		c, _, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "containers")
+		Expect(err).NotTo(HaveOccurred())
+		return c
+	}
+
+	setEnv := func(env ...interface{}) {
+		c := containers(app.Resource())
+		c[0].(map[string]interface{})["env"] = env
+		Expect(unstructured.SetNestedSlice(app.Resource().Object, c, "spec", "template", "spec", "containers")).To(Succeed())
+	}
+
+	It("should hold only fields set by the pipeline", func() {
+		setEnv(
+			map[string]interface{}{"name": "USER_VAR", "value": "v"},
+			map[string]interface{}{"name": "SERVICE_BINDING_ROOT", "value": "/bindings"},
+		)
+
+		config, complete, err := app.applyConfiguration()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(complete).To(BeTrue())
+		Expect(config.GetName()).To(Equal("app"))
+		Expect(config.GetNamespace()).To(Equal("ns1"))
+		Expect(config.GetResourceVersion()).To(Equal("5"))
+		Expect(config.GetKind()).To(Equal("Deployment"))
+		Expect(config.Object["spec"]).To(Equal(map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name": "c1",
+							"env": []interface{}{
+								map[string]interface{}{"name": "SERVICE_BINDING_ROOT", "value": "/bindings"},
+							},
+						},
+					},
+				},
+			},
+		}))
+	})
+
+	It("should keep fields owned by the operator", func() {
+		app.persistedResource.SetManagedFields([]metav1.ManagedFieldsEntry{
+			{
+				Manager:   "kubectl",
+				Operation: metav1.ManagedFieldsOperationApply,
+				FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+			},
+			{
+				Manager:   FieldManager,
+				Operation: metav1.ManagedFieldsOperationApply,
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"c1\"}":{".":{},"f:name":{},` +
+					`"f:env":{"k:{\"name\":\"USER_VAR\"}":{".":{},"f:name":{},"f:value":{}}}}}}}}}`)},
+			},
+		})
+		setEnv(
+			map[string]interface{}{"name": "USER_VAR", "value": "v"},
+			map[string]interface{}{"name": "SERVICE_BINDING_ROOT", "value": "/bindings"},
+		)
+
+		config, complete, err := app.applyConfiguration()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(complete).To(BeTrue())
+		Expect(containers(config)).To(Equal([]interface{}{
+			map[string]interface{}{
+				"name": "c1",
+				"env": []interface{}{
+					map[string]interface{}{"name": "USER_VAR", "value": "v"},
+					map[string]interface{}{"name": "SERVICE_BINDING_ROOT", "value": "/bindings"},
+				},
+			},
+		}))
+		_, found, _ := unstructured.NestedFieldNoCopy(config.Object, "spec", "replicas")
+		Expect(found).To(BeFalse())
+	})
+
+	It("should omit removed fields owned by the operator", func() {
+		app.persistedResource.SetManagedFields([]metav1.ManagedFieldsEntry{
+			{
+				Manager:   FieldManager,
+				Operation: metav1.ManagedFieldsOperationApply,
+				FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"c1\"}":{".":{},"f:name":{},` +
+					`"f:env":{"k:{\"name\":\"USER_VAR\"}":{".":{},"f:name":{},"f:value":{}}}}}}}}}`)},
+			},
+		})
+		setEnv()
+
+		config, complete, err := app.applyConfiguration()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(complete).To(BeTrue())
+		Expect(containers(config)).To(Equal([]interface{}{
+			map[string]interface{}{"name": "c1"},
+		}))
+	})
+
+	It("should hold whole lists of custom resources", func() {
+		app.persistedResource.SetAPIVersion("serving.knative.dev/v1")
+		app.persistedResource.SetKind("Service")
+		setEnv(
+			map[string]interface{}{"name": "USER_VAR", "value": "v"},
+			map[string]interface{}{"name": "SERVICE_BINDING_ROOT", "value": "/bindings"},
+		)
+
+		config, complete, err := app.applyConfiguration()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(complete).To(BeTrue())
+		Expect(containers(config)).To(Equal([]interface{}{
+			map[string]interface{}{
+				"name":  "c1",
+				"image": "foo",
+				"env": []interface{}{
+					map[string]interface{}{"name": "USER_VAR", "value": "v"},
+					map[string]interface{}{"name": "SERVICE_BINDING_ROOT", "value": "/bindings"},
+				},
+			},
+		}))
+	})
+
+	It("should report removed fields not owned by the operator", func() {
+		setEnv()
+
+		_, complete, err := app.applyConfiguration()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(complete).To(BeFalse())
+	})
+})
@@ -0,0 +1,34 @@
+package context
+
+import (
+	"fmt"
+
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+var _ pipeline.EventRecorder = &eventRecorder{}
+
+// eventRecorder records events about a service binding, events are dropped if no recorder is configured
+type eventRecorder struct {
+	recorder    record.EventRecorder
+	binding     runtime.Object
+	bindingName string
+}
+
+func (r *eventRecorder) Event(eventType string, reason string, message string) {
+	if r.recorder == nil {
+		return
+	}
+	r.recorder.Event(r.binding, eventType, reason, message)
+}
+
+func (r *eventRecorder) WorkloadEvent(app pipeline.Application, eventType string, reason string, message string) {
+	if r.recorder == nil {
+		return
+	}
+	workload := app.Resource()
+	r.recorder.Event(r.binding, eventType, reason, fmt.Sprintf("%s %s: %s", app.GroupVersionResource().Resource, workload.GetName(), message))
+	r.recorder.Event(workload, eventType, reason, fmt.Sprintf("Service binding %s: %s", r.bindingName, message))
+}
@@ -3,28 +3,30 @@ package context
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
+	mergepatch "github.com/evanphx/json-patch"
 	"github.com/redhat-developer/service-binding-operator/apis"
 	"github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/apis/spec/v1beta1"
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/context/service"
-	"golang.org/x/time/rate"
 	authv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/authorization/v1"
 	clientauthzv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
-	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/converter"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/metrics"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
-	"github.com/redhat-developer/service-binding-operator/pkg/util"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,7 +34,10 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/tools/record"
 )
 
 var (
@@ -43,6 +48,9 @@ var (
 type impl struct {
 	client dynamic.Interface
 
+	// reads resources from the cache if any, from the API server otherwise
+	reader dynamic.Interface
+
 	subjectAccessReviewClient clientauthzv1.SubjectAccessReviewInterface
 
 	typeLookup kubernetes.K8STypeLookup
@@ -70,6 +78,8 @@ type impl struct {
 
 	statusConditions func() *[]metav1.Condition
 
+	workloadStatuses func() *[]apis.WorkloadStatus
+
 	ownerReference func() metav1.OwnerReference
 
 	groupVersionResource func() schema.GroupVersionResource
@@ -80,7 +90,29 @@ type impl struct {
 
 	resourceMapping *pipeline.WorkloadMapping
 
-	labelSelectionRateLimiter workqueue.RateLimiter
+	tracker pipeline.ResourceTracker
+
+	referencedResources []pipeline.ResourceReference
+
+	crossNamespaceAllowList []string
+
+	crossNamespaceReferences []apis.CrossNamespaceReference
+
+	setCrossNamespaceReferences func(refs []apis.CrossNamespaceReference)
+
+	dryRun bool
+
+	setDryRunResult func(result *apis.DryRunResult)
+
+	failurePolicy apis.FailurePolicy
+
+	eventRecorder pipeline.EventRecorder
+
+	// context of client calls, carrying the span of the current handler
+	requestCtx context.Context
+
+	// binding as last persisted, so that it is written only when modified
+	persistedBinding *unstructured.Unstructured
 }
 
 type bindingImpl struct {
@@ -93,69 +125,168 @@ func (i *impl) UnbindRequested() bool {
 	return found || i.IsRemoved()
 }
 
+func (i *impl) FailurePolicy() apis.FailurePolicy {
+	if i.failurePolicy == "" {
+		return apis.FailurePolicyFailFast
+	}
+	return i.failurePolicy
+}
+
+func (i *impl) EventRecorder() pipeline.EventRecorder {
+	if i.eventRecorder == nil {
+		return &eventRecorder{}
+	}
+	return i.eventRecorder
+}
+
+func (i *impl) SetRequestContext(ctx context.Context) {
+	i.requestCtx = ctx
+}
+
+func (i *impl) requestContext() context.Context {
+	if i.requestCtx == nil {
+		return context.Background()
+	}
+	return i.requestCtx
+}
+
 func (i *impl) IsRemoved() bool {
 	return !i.bindingMeta.DeletionTimestamp.IsZero()
 }
 
 type provider struct {
-	client     dynamic.Interface
-	typeLookup kubernetes.K8STypeLookup
-	get        func(binding interface{}) (pipeline.Context, error)
+	client                  dynamic.Interface
+	typeLookup              kubernetes.K8STypeLookup
+	tracker                 pipeline.ResourceTracker
+	crossNamespaceAllowList []string
+	recorder                record.EventRecorder
+	cache                   pipeline.ResourceCache
+	get                     func(binding interface{}) (pipeline.Context, error)
+}
+
+// reader returns the client reading resources referred by bindings
+func (p *provider) reader() dynamic.Interface {
+	if p.cache == nil {
+		return p.client
+	}
+	return p.cache.Client(p.client)
 }
 
 func (p *provider) Get(binding interface{}) (pipeline.Context, error) {
 	return p.get(binding)
 }
 
-var Provider = func(client dynamic.Interface, subjectAccessReviewClient clientauthzv1.SubjectAccessReviewInterface, typeLookup kubernetes.K8STypeLookup) pipeline.ContextProvider {
-	return &provider{
+type ProviderOption func(p *provider)
+
+// Report resources read while processing bindings to the given tracker
+func WithResourceTracker(tracker pipeline.ResourceTracker) ProviderOption {
+	return func(p *provider) {
+		p.tracker = tracker
+	}
+}
+
+// Read services, workloads, secrets, config maps and related resources from the given cache
+func WithResourceCache(cache pipeline.ResourceCache) ProviderOption {
+	return func(p *provider) {
+		p.cache = cache
+	}
+}
+
+// Allow services to reference secrets and config maps in the given namespaces, `*` allowing any namespace;
+// by default services can reference only secrets and config maps in their own namespace
+func WithCrossNamespaceAllowList(namespaces []string) ProviderOption {
+	return func(p *provider) {
+		for _, ns := range namespaces {
+			if ns = strings.TrimSpace(ns); ns != "" {
+				p.crossNamespaceAllowList = append(p.crossNamespaceAllowList, ns)
+			}
+		}
+	}
+}
+
+// Record events about processed bindings and their workloads with the given recorder; by default no events are recorded
+func WithEventRecorder(recorder record.EventRecorder) ProviderOption {
+	return func(p *provider) {
+		p.recorder = recorder
+	}
+}
+
+func newProvider(client dynamic.Interface, typeLookup kubernetes.K8STypeLookup, opts []ProviderOption) *provider {
+	p := &provider{
 		client:     client,
 		typeLookup: typeLookup,
-		get: func(binding interface{}) (pipeline.Context, error) {
-			switch sb := binding.(type) {
-			case *v1alpha1.ServiceBinding:
-				return &bindingImpl{
-					impl: impl{
-						conditions:                make(map[string]*metav1.Condition),
-						client:                    client,
-						subjectAccessReviewClient: subjectAccessReviewClient,
-						typeLookup:                typeLookup,
-						bindingMeta:               &sb.ObjectMeta,
-						statusSecretName: func() string {
-							return sb.Status.Secret
-						},
-						setStatusSecretName: func(name string) {
-							sb.Status.Secret = name
-						},
-						unstructuredBinding: func() (*unstructured.Unstructured, error) {
-							return converter.ToUnstructured(sb)
-						},
-						statusConditions: func() *[]metav1.Condition {
-							return &sb.Status.Conditions
-						},
-						ownerReference: func() metav1.OwnerReference {
-							return sb.AsOwnerReference()
-						},
-						groupVersionResource: func() schema.GroupVersionResource {
-							return v1alpha1.GroupVersionResource
-						},
-						requester: func() *authv1.UserInfo {
-							return apis.Requester(sb.ObjectMeta)
-						},
-						serviceBuilder: service.NewBuilder(typeLookup).WithClient(client).LookOwnedResources(sb.Spec.DetectBindingResources),
-						labelSelectionRateLimiter: workqueue.NewMaxOfRateLimiter(
-							workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 2*time.Minute),
-							&workqueue.BucketRateLimiter{
-								Limiter: rate.NewLimiter(rate.Limit(10), 100),
-							},
-						),
-					},
-					serviceBinding: sb,
-				}, nil
+	}
+	for _, o := range opts {
+		o(p)
+	}
+	return p
+}
+
+var Provider = func(client dynamic.Interface, subjectAccessReviewClient clientauthzv1.SubjectAccessReviewInterface, typeLookup kubernetes.K8STypeLookup, opts ...ProviderOption) pipeline.ContextProvider {
+	p := newProvider(client, typeLookup, opts)
+	p.get = func(binding interface{}) (pipeline.Context, error) {
+		switch sb := binding.(type) {
+		case *v1alpha1.ServiceBinding:
+			persisted, err := converter.ToUnstructured(sb)
+			if err != nil {
+				return nil, err
 			}
-			return nil, fmt.Errorf("cannot create context for passed instance %v", binding)
-		},
+			// references are recorded again while collecting binding data
+			sb.Status.CrossNamespaceReferences = nil
+			sb.Status.DryRun = nil
+			reader := p.reader()
+			return &bindingImpl{
+				impl: impl{
+					conditions:                make(map[string]*metav1.Condition),
+					client:                    client,
+					reader:                    reader,
+					subjectAccessReviewClient: subjectAccessReviewClient,
+					typeLookup:                typeLookup,
+					tracker:                   p.tracker,
+					crossNamespaceAllowList:   p.crossNamespaceAllowList,
+					bindingMeta:               &sb.ObjectMeta,
+					statusSecretName: func() string {
+						return sb.Status.Secret
+					},
+					setStatusSecretName: func(name string) {
+						sb.Status.Secret = name
+					},
+					setCrossNamespaceReferences: func(refs []apis.CrossNamespaceReference) {
+						sb.Status.CrossNamespaceReferences = refs
+					},
+					dryRun:        sb.Spec.DryRun || apis.DryRunRequested(sb.ObjectMeta),
+					failurePolicy: sb.Spec.FailurePolicy,
+					eventRecorder: &eventRecorder{recorder: p.recorder, binding: sb, bindingName: sb.Name},
+					setDryRunResult: func(result *apis.DryRunResult) {
+						sb.Status.DryRun = result
+					},
+					unstructuredBinding: func() (*unstructured.Unstructured, error) {
+						return converter.ToUnstructured(sb)
+					},
+					statusConditions: func() *[]metav1.Condition {
+						return &sb.Status.Conditions
+					},
+					workloadStatuses: func() *[]apis.WorkloadStatus {
+						return &sb.Status.Workloads
+					},
+					ownerReference: func() metav1.OwnerReference {
+						return sb.AsOwnerReference()
+					},
+					groupVersionResource: func() schema.GroupVersionResource {
+						return v1alpha1.GroupVersionResource
+					},
+					requester: func() *authv1.UserInfo {
+						return apis.Requester(sb.ObjectMeta)
+					},
+					serviceBuilder:   service.NewBuilder(typeLookup).WithClient(reader).LookOwnedResources(sb.Spec.DetectBindingResources),
+					persistedBinding: persisted,
+				},
+				serviceBinding: sb,
+			}, nil
+		}
+		return nil, fmt.Errorf("cannot create context for passed instance %v", binding)
 	}
+	return p
 }
 
 func (i *bindingImpl) BindingName() string {
@@ -192,7 +323,7 @@ func (i *impl) canPerform(gvr *schema.GroupVersionResource, name string, namespa
 	if userInfo == nil {
 		return true
 	}
-	sar, err := i.subjectAccessReviewClient.Create(context.Background(), &v1.SubjectAccessReview{
+	sar, err := i.subjectAccessReviewClient.Create(i.requestContext(), &v1.SubjectAccessReview{
 		Spec: v1.SubjectAccessReviewSpec{
 			ResourceAttributes: &v1.ResourceAttributes{
 				Namespace: namespace,
@@ -225,19 +356,20 @@ func (i *bindingImpl) Services() ([]pipeline.Service, error) {
 			if serviceRef.Namespace == nil {
 				serviceRef.Namespace = &i.serviceBinding.Namespace
 			}
+			i.trackResource(*gvr, *serviceRef.Namespace, serviceRef.Name)
 
 			if !i.canPerform(gvr, serviceRef.Name, *serviceRef.Namespace, "get") {
 				return nil, fmt.Errorf("cannot read service %s in namespace %s", serviceRef.Name, *serviceRef.Namespace)
 			}
-			u, err := i.client.Resource(*gvr).Namespace(*serviceRef.Namespace).Get(context.Background(), serviceRef.Name, metav1.GetOptions{})
+			u, err := i.reader.Resource(*gvr).Namespace(*serviceRef.Namespace).Get(i.requestContext(), serviceRef.Name, metav1.GetOptions{})
 			if err != nil {
 				return nil, err
 			}
 			var s pipeline.Service
 			if serviceRef.Id != nil {
-				s, err = i.serviceBuilder.Build(u, service.Id(*serviceRef.Id))
+				s, err = i.serviceBuilder.Build(u, service.Id(*serviceRef.Id), service.RequestContextOption(i.requestContext))
 			} else {
-				s, err = i.serviceBuilder.Build(u)
+				s, err = i.serviceBuilder.Build(u, service.RequestContextOption(i.requestContext))
 			}
 			if err != nil {
 				return nil, err
@@ -275,7 +407,9 @@ func (i *bindingImpl) Applications() ([]pipeline.Application, error) {
 			if !i.canPerform(gvr, ref.Name, i.serviceBinding.Namespace, "update") {
 				return nil, fmt.Errorf("cannot update application resource %s in namespace %s", ref.Name, i.serviceBinding.Namespace)
 			}
-			u, err := i.client.Resource(*gvr).Namespace(i.serviceBinding.Namespace).Get(context.Background(), ref.Name, metav1.GetOptions{})
+			// workloads are read from the API server, as they are applied with their resource version as precondition
+			// and cached copies might lag behind
+			u, err := i.client.Resource(*gvr).Namespace(i.serviceBinding.Namespace).Get(i.requestContext(), ref.Name, metav1.GetOptions{})
 			if err != nil {
 				return nil, emptyApplicationsErr{err}
 			}
@@ -288,15 +422,19 @@ func (i *bindingImpl) Applications() ([]pipeline.Application, error) {
 			})
 		}
 		if i.HasLabelSelector() {
-			matchLabels := i.serviceBinding.Spec.Application.LabelSelector.MatchLabels
+			selector, err := metav1.LabelSelectorAsSelector(i.serviceBinding.Spec.Application.LabelSelector)
+			if err != nil {
+				return nil, err
+			}
+			i.trackSelectedResources(*gvr, i.serviceBinding.Namespace, selector)
 			opts := metav1.ListOptions{
-				LabelSelector: labels.Set(matchLabels).String(),
+				LabelSelector: selector.String(),
 			}
 			if !i.canPerform(gvr, "", i.serviceBinding.Namespace, "list") {
 				return nil, fmt.Errorf("cannot read application in namespace %s", i.serviceBinding.Namespace)
 			}
 
-			objList, err := i.client.Resource(*gvr).Namespace(i.serviceBinding.Namespace).List(context.Background(), opts)
+			objList, err := i.client.Resource(*gvr).Namespace(i.serviceBinding.Namespace).List(i.requestContext(), opts)
 			if err != nil {
 				return nil, err
 			}
@@ -371,7 +509,31 @@ func (i *impl) bindingSecretName() (string, bool) {
 			return ref.Name, true
 		}
 	}
-	data := i.bindingItemMap()
+	return i.bindingMeta.Name + "-" + i.secretNameHash()[:8], false
+}
+
+// secretNameHash returns the hash of binding data the binding secret is named after; keys and values are
+// concatenated as they are, and structured values rendered as they were before being projected as JSON,
+// so that secrets of existing bindings keep their names
+func (i *impl) secretNameHash() string {
+	data := make(map[string][]byte)
+	add := func(items pipeline.BindingItems) {
+		for _, item := range items {
+			if item.Type() == pipeline.StructuredValue {
+				data[item.Name] = []byte(fmt.Sprintf("%v", item.Value))
+			} else {
+				data[item.Name] = item.Bytes()
+			}
+		}
+	}
+	for _, b := range i.bindings {
+		items, err := b.Items()
+		if err != nil {
+			continue
+		}
+		add(items)
+	}
+	add(i.bindingItems)
 	keys := make([]string, 0, len(data))
 	for k := range data {
 		keys = append(keys, k)
@@ -380,22 +542,45 @@ func (i *impl) bindingSecretName() (string, bool) {
 	hash := sha256.New()
 	for _, k := range keys {
 		_, _ = hash.Write([]byte(k))
-		_, _ = hash.Write([]byte(data[k]))
+		_, _ = hash.Write(data[k])
 	}
-	return i.bindingMeta.Name + "-" + string(hex.EncodeToString(hash.Sum(nil))[:8]), false
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
-func (i *impl) bindingItemMap() map[string]string {
-	data := make(map[string]string)
+func (i *impl) BindingDataHash() string {
+	data := i.bindingItemMap()
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	// keys and values are length-prefixed, so that the hash is stable
+	// and does not depend on where a key ends and its value begins
+	hash := sha256.New()
+	length := make([]byte, 8)
+	for _, k := range keys {
+		for _, b := range [][]byte{[]byte(k), data[k]} {
+			binary.BigEndian.PutUint64(length, uint64(len(b)))
+			_, _ = hash.Write(length)
+			_, _ = hash.Write(b)
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (i *impl) bindingItemMap() map[string][]byte {
+	data := make(map[string][]byte)
 	for _, b := range i.bindings {
 		items, err := b.Items()
 		if err != nil {
 			continue
 		}
-		util.MergeMaps(data, items.AsMap())
+		for k, v := range items.AsByteMap() {
+			data[k] = v
+		}
 	}
-	if len(i.bindingItems) > 0 {
-		util.MergeMaps(data, i.bindingItems.AsMap())
+	for k, v := range i.bindingItems.AsByteMap() {
+		data[k] = v
 	}
 	return data
 }
@@ -408,6 +593,13 @@ func (i *bindingImpl) BindAsFiles() bool {
 	return i.serviceBinding.Spec.BindAsFiles
 }
 
+func (i *bindingImpl) RestartPolicy() apis.RestartPolicy {
+	if i.serviceBinding.Spec.RestartPolicy == "" {
+		return apis.RestartPolicyNever
+	}
+	return i.serviceBinding.Spec.RestartPolicy
+}
+
 func (i *impl) persistBinding() error {
 	if i.bindingMeta.UID == "" {
 		return nil
@@ -420,12 +612,73 @@ func (i *impl) persistBinding() error {
 		return err
 	}
 	client := i.client.Resource(i.groupVersionResource()).Namespace(i.bindingMeta.Namespace)
-	_, err = client.UpdateStatus(context.Background(), u, metav1.UpdateOptions{})
+	var persisted map[string]interface{}
+	if i.persistedBinding != nil {
+		persisted = i.persistedBinding.Object
+	}
+	if patch, err := mergePatch(persisted, u.Object, []string{"status"}); err != nil || patch != nil {
+		if err != nil {
+			return err
+		}
+		result, err := client.Patch(i.requestContext(), i.bindingMeta.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status")
+		if err != nil {
+			return err
+		}
+		i.bindingMeta.ResourceVersion = result.GetResourceVersion()
+	}
+	if patch, err := mergePatch(persisted, u.Object, []string{"metadata", "annotations"}, []string{"metadata", "finalizers"}); err != nil || patch != nil {
+		if err != nil {
+			return err
+		}
+		result, err := client.Patch(i.requestContext(), i.bindingMeta.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return err
+		}
+		i.bindingMeta.ResourceVersion = result.GetResourceVersion()
+	}
+	i.persistedBinding = u
+	return nil
+}
+
+// mergePatch returns the JSON merge patch (RFC 7386) of the given fields, turning their persisted values into the
+// updated ones, or nil if the fields are unchanged; finalizers are patched on the persisted resource version only,
+// as merge patches replace lists as a whole
+func mergePatch(persisted, updated map[string]interface{}, fields ...[]string) ([]byte, error) {
+	original := make(map[string]interface{})
+	modified := make(map[string]interface{})
+	for _, f := range fields {
+		if v, found, _ := unstructured.NestedFieldNoCopy(persisted, f...); found {
+			if err := unstructured.SetNestedField(original, v, f...); err != nil {
+				return nil, err
+			}
+		}
+		if v, found, _ := unstructured.NestedFieldNoCopy(updated, f...); found {
+			if err := unstructured.SetNestedField(modified, v, f...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if equality.Semantic.DeepEqual(original, modified) {
+		return nil, nil
+	}
+	of, _, _ := unstructured.NestedFieldNoCopy(original, "metadata", "finalizers")
+	mf, _, _ := unstructured.NestedFieldNoCopy(modified, "metadata", "finalizers")
+	if !equality.Semantic.DeepEqual(of, mf) {
+		if rv, found, _ := unstructured.NestedString(persisted, "metadata", "resourceVersion"); found {
+			if err := unstructured.SetNestedField(modified, rv, "metadata", "resourceVersion"); err != nil {
+				return nil, err
+			}
+		}
+	}
+	o, err := json.Marshal(original)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	_, err = client.Update(context.Background(), u, metav1.UpdateOptions{})
-	return err
+	m, err := json.Marshal(modified)
+	if err != nil {
+		return nil, err
+	}
+	return mergepatch.CreateMergePatch(o, m)
 }
 
 func (i *impl) persistSecret() (string, error) {
@@ -437,12 +690,13 @@ func (i *impl) persistSecret() (string, error) {
 	if len(data) == 0 {
 		return "", nil
 	}
+	// data are written as bytes, as string data would not preserve binary values
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: i.bindingMeta.Namespace,
 			Name:      name,
 		},
-		StringData: data,
+		Data: data,
 	}
 	if i.bindingMeta.UID != "" {
 		secret.OwnerReferences = []metav1.OwnerReference{i.ownerReference()}
@@ -454,19 +708,37 @@ func (i *impl) persistSecret() (string, error) {
 
 	secretClient := i.client.Resource(corev1.SchemeGroupVersion.WithResource("secrets")).Namespace(i.bindingMeta.Namespace)
 
-	_, err = secretClient.Get(context.Background(), name, metav1.GetOptions{})
+	existing, err := secretClient.Get(i.requestContext(), name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
-			_, err = secretClient.Create(context.Background(), u, metav1.CreateOptions{})
+			_, err = secretClient.Create(i.requestContext(), u, metav1.CreateOptions{})
+			if err == nil {
+				metrics.SecretWrites.WithLabelValues("create").Inc()
+				i.EventRecorder().Event(corev1.EventTypeNormal, "SecretCreated", fmt.Sprintf("Created binding secret %s", name))
+				i.EventRecorder().Event(corev1.EventTypeNormal, apis.DataCollectedReason, "Binding data collected")
+			}
 			return name, err
 		}
 		return name, err
 	}
-	_, err = secretClient.Update(context.Background(), u, metav1.UpdateOptions{})
+	if equality.Semantic.DeepEqual(existing.Object["data"], u.Object["data"]) &&
+		equality.Semantic.DeepEqual(existing.GetOwnerReferences(), u.GetOwnerReferences()) {
+		return name, nil
+	}
+	_, err = secretClient.Update(i.requestContext(), u, metav1.UpdateOptions{})
+	if err == nil {
+		metrics.SecretWrites.WithLabelValues("update").Inc()
+		i.EventRecorder().Event(corev1.EventTypeNormal, "SecretUpdated", fmt.Sprintf("Updated binding secret %s", name))
+		i.EventRecorder().Event(corev1.EventTypeNormal, apis.DataCollectedReason, "Binding data collected")
+	}
 	return name, err
 }
 
 func (i *impl) PersistSecret() error {
+	if i.dryRun {
+		// the secret name is reported in the dry-run result instead
+		return nil
+	}
 	secretName, err := i.persistSecret()
 	if err != nil {
 		i.SetCondition(apis.Conditions().NotBindingReady().Reason("ErrorPersistingSecret").Msg(err.Error()).Build())
@@ -480,59 +752,247 @@ func (i *impl) PersistSecret() error {
 }
 
 func (i *impl) Close() error {
+	i.trackReferencedResources()
 	if i.err != nil {
 		i.SetCondition(apis.Conditions().NotBindingReady().Reason("ProcessingError").Msg(i.err.Error()).Build())
+		i.reportWorkloads(i.err)
 		return i.persistBinding()
 	}
+	if i.dryRun {
+		return i.closeDryRun()
+	}
+	bestEffort := i.FailurePolicy() == apis.FailurePolicyBestEffort
+	var failures []error
+	unbound := false
 	for _, app := range i.applications {
+		a, _ := app.(*application)
+		if a != nil && a.err != nil {
+			// binding data could not be injected, the application is left untouched
+			failures = append(failures, workloadError(app, a.err))
+			continue
+		}
 		if app.IsUpdated() {
-			// We explicitly want to clone our app object here.  This is because we need to pass this
-			// object through DeepCopyJSON(), which currently doesn't allow []map[string]interface{}
-			// objects.  To fix this, we have to normalize them into []interface{} slices, where
-			// every element of that slice is of type map[string]interface{}.
-			clone, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&app.Resource().Object)
-			if err != nil {
-				return err
-			}
-
-			resource := &unstructured.Unstructured{Object: clone}
-			_, err = i.client.
-				Resource(app.GroupVersionResource()).
-				Namespace(i.bindingMeta.Namespace).
-				Update(context.Background(), resource, metav1.UpdateOptions{})
+			bound, err := i.updateApplication(app)
 			if err != nil {
+				if errors.IsConflict(err) {
+					// the workload has been modified since it was read, the pipeline is run again against its latest version
+					return err
+				}
+				if a != nil {
+					a.setError("ApplicationUpdateError", err)
+				}
+				i.EventRecorder().WorkloadEvent(app, corev1.EventTypeWarning, "ApplicationUpdateError", err.Error())
+				if bestEffort {
+					failures = append(failures, workloadError(app, err))
+					continue
+				}
 				i.SetCondition(apis.Conditions().
 					NotBindingReady().
 					Reason("ApplicationUpdateError").
 					Msg(err.Error()).
 					Build())
+				i.reportWorkloads(err)
 				_ = i.persistBinding()
 				return err
 			}
+			if a != nil {
+				a.bound, a.boundGeneration = true, bound.GetGeneration()
+			}
+			if i.UnbindRequested() {
+				unbound = true
+				i.EventRecorder().WorkloadEvent(app, corev1.EventTypeNormal, apis.UnboundReason, "Binding data removed")
+			} else {
+				i.EventRecorder().WorkloadEvent(app, corev1.EventTypeNormal, apis.BindingInjectedReason, "Binding data injected")
+			}
+		} else if a != nil {
+			a.bound, a.boundGeneration = true, a.persistedResource.GetGeneration()
 		}
 	}
+	if len(failures) > 0 {
+		// bound workloads are left as they are when processing is retried, so that only failed workloads are bound again
+		err := fmt.Errorf("%d of %d workloads not bound: %w", len(failures), len(i.applications), utilerrors.NewAggregate(failures))
+		i.SetCondition(apis.Conditions().NotInjectionReady().Reason(apis.WorkloadsNotBoundReason).Msg(err.Error()).Build())
+		i.SetCondition(apis.Conditions().NotBindingReady().Reason(apis.WorkloadsNotBoundReason).Msg(err.Error()).Build())
+		i.reportWorkloads(nil)
+		if perr := i.persistBinding(); perr != nil {
+			return perr
+		}
+		return err
+	}
+	if unbound {
+		i.EventRecorder().Event(corev1.EventTypeNormal, apis.UnboundReason, "Workloads unbound")
+	}
 	i.SetCondition(apis.Conditions().BindingReady().Reason("ApplicationsBound").Build())
+	i.reportWorkloads(nil)
+	return i.persistBinding()
+}
+
+func workloadError(app pipeline.Application, err error) error {
+	return fmt.Errorf("%s %s: %w", app.GroupVersionResource().Resource, app.Resource().GetName(), err)
+}
+
+// reportWorkloads records the binding of each application into the binding status, the given error having
+// stopped processing before applications not bound yet got bound. When unbinding, applications binding data
+// has been removed from are not reported anymore.
+func (i *impl) reportWorkloads(stopErr error) {
+	unbind := i.UnbindRequested()
+	statuses := i.workloadStatuses()
+	var result []apis.WorkloadStatus
+	for _, app := range i.applications {
+		if a, ok := app.(*application); ok {
+			if unbind && a.bound {
+				continue
+			}
+			result = append(result, a.status(*statuses, stopErr))
+		}
+	}
+	*statuses = result
+}
+
+// updateApplication applies the fields set by the pipeline on the application using the operator field manager,
+// so that fields set by other managers are left untouched, and returns the persisted application
+func (i *impl) updateApplication(app pipeline.Application) (*unstructured.Unstructured, error) {
+	client := i.client.Resource(app.GroupVersionResource()).Namespace(i.bindingMeta.Namespace)
+	a, ok := app.(*application)
+	if ok {
+		config, complete, err := a.applyConfiguration()
+		if err != nil {
+			return nil, err
+		}
+		if complete {
+			return client.Apply(i.requestContext(), config.GetName(), config, metav1.ApplyOptions{FieldManager: FieldManager, Force: true})
+		}
+		// fields not owned by the operator, e.g. bound before workloads were applied, can only be removed by an update
+	}
+	// We explicitly want to clone our app object here.  This is because we need to pass this
+	// object through DeepCopyJSON(), which currently doesn't allow []map[string]interface{}
+	// objects.  To fix this, we have to normalize them into []interface{} slices, where
+	// every element of that slice is of type map[string]interface{}.
+	clone, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&app.Resource().Object)
+	if err != nil {
+		return nil, err
+	}
+	return client.Update(i.requestContext(), &unstructured.Unstructured{Object: clone}, metav1.UpdateOptions{FieldManager: FieldManager})
+}
+
+// closeDryRun reports the binding keys and workload changes into the binding status, without modifying workloads
+func (i *impl) closeDryRun() error {
+	result := &apis.DryRunResult{Secret: i.BindingSecretName()}
+	for k := range i.bindingItemMap() {
+		result.BindingKeys = append(result.BindingKeys, k)
+	}
+	sort.Strings(result.BindingKeys)
+	for _, app := range i.applications {
+		a, ok := app.(*application)
+		if !ok || !a.IsUpdated() {
+			continue
+		}
+		patch, err := a.jsonPatch()
+		if err != nil {
+			return err
+		}
+		gvr := a.GroupVersionResource()
+		result.Workloads = append(result.Workloads, apis.WorkloadPatch{
+			Group:    gvr.Group,
+			Version:  gvr.Version,
+			Resource: gvr.Resource,
+			Name:     a.Resource().GetName(),
+			Patch:    patch,
+		})
+	}
+	i.setDryRunResult(result)
+	i.SetCondition(apis.Conditions().NotBindingReady().Reason(apis.DryRunReason).Msg("Workloads are not modified in dry-run mode").Build())
 	return i.persistBinding()
 }
 
+func (i *impl) trackResource(gvr schema.GroupVersionResource, namespace string, name string) {
+	i.referencedResources = append(i.referencedResources, pipeline.ResourceReference{
+		GroupVersionResource: gvr,
+		Namespace:            namespace,
+		Name:                 name,
+	})
+}
+
+func (i *impl) trackSelectedResources(gvr schema.GroupVersionResource, namespace string, selector labels.Selector) {
+	i.referencedResources = append(i.referencedResources, pipeline.ResourceReference{
+		GroupVersionResource: gvr,
+		Namespace:            namespace,
+		Selector:             selector,
+	})
+}
+
+func (i *impl) trackReferencedResources() {
+	if i.tracker == nil {
+		return
+	}
+	resources := i.referencedResources
+	if i.IsRemoved() {
+		resources = nil
+	}
+	unwatched := i.tracker.Track(types.NamespacedName{Namespace: i.bindingMeta.Namespace, Name: i.bindingMeta.Name}, resources)
+	for _, r := range unwatched {
+		if r.Selector != nil {
+			// workloads matching the label selector cannot be watched, poll for them instead
+			if !i.FlowStatus().Retry {
+				i.DelayReprocessing(i.err)
+			}
+			return
+		}
+	}
+}
+
+// LabelSelectionResyncPeriod is the delay of reprocessing bindings selecting workloads by labels,
+// when changes of such workloads cannot be watched
+var LabelSelectionResyncPeriod = time.Minute
+
+func (i *impl) DelayReprocessing(err error) {
+	i.RetryProcessingWithDelay(err, LabelSelectionResyncPeriod)
+}
+
 func (i *impl) SetCondition(condition *metav1.Condition) {
+	if condition.Status == metav1.ConditionFalse {
+		metrics.Errors.WithLabelValues(condition.Type, condition.Reason).Inc()
+	}
 	i.conditions[condition.Type] = condition
 }
 
 func (i *impl) ReadConfigMap(namespace string, name string) (*unstructured.Unstructured, error) {
 	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "configmaps"}
+	i.trackResource(gvr, namespace, name)
 	if !i.canPerform(&gvr, name, namespace, "get") {
 		return nil, fmt.Errorf("cannot read configmap %s in namespace %s", name, namespace)
 	}
-	return i.client.Resource(gvr).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	return i.reader.Resource(gvr).Namespace(namespace).Get(i.requestContext(), name, metav1.GetOptions{})
 }
 
 func (i *impl) ReadSecret(namespace string, name string) (*unstructured.Unstructured, error) {
 	gvr := schema.GroupVersionResource{Group: "", Version: "v1", Resource: "secrets"}
+	i.trackResource(gvr, namespace, name)
 	if !i.canPerform(&gvr, name, namespace, "get") {
 		return nil, fmt.Errorf("cannot read secret %s in namespace %s", name, namespace)
 	}
-	return i.client.Resource(gvr).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	return i.reader.Resource(gvr).Namespace(namespace).Get(i.requestContext(), name, metav1.GetOptions{})
+}
+
+func (i *impl) AuthorizeCrossNamespaceReference(ref apis.CrossNamespaceReference) error {
+	allowed := false
+	for _, ns := range i.crossNamespaceAllowList {
+		if ns == "*" || ns == ref.Namespace {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return fmt.Errorf("cross-namespace reference to %s %s in namespace %s is not allowed", strings.ToLower(ref.Kind), ref.Name, ref.Namespace)
+	}
+	for _, r := range i.crossNamespaceReferences {
+		if r == ref {
+			return nil
+		}
+	}
+	i.crossNamespaceReferences = append(i.crossNamespaceReferences, ref)
+	i.setCrossNamespaceReferences(i.crossNamespaceReferences)
+	return nil
 }
 
 func (i *impl) AddBindings(bindings pipeline.Bindings) {
@@ -558,8 +1018,8 @@ func (i *impl) WorkloadResourceTemplate(gvr *schema.GroupVersionResource, contai
 			return nil, err
 		}
 	} else {
-		mappingObj, err := i.client.Resource(mappingGVR).
-			Get(context.Background(),
+		mappingObj, err := i.reader.Resource(mappingGVR).
+			Get(i.requestContext(),
 				gvr.GroupResource().String(),
 				metav1.GetOptions{})
 		if errors.IsNotFound(err) {
@@ -618,8 +1078,3 @@ func (i *impl) WorkloadResourceTemplate(gvr *schema.GroupVersionResource, contai
 func (i *bindingImpl) HasLabelSelector() bool {
 	return i.serviceBinding.Spec.Application.LabelSelector != nil
 }
-
-func (i *impl) DelayReprocessing(err error) {
-	delay := i.labelSelectionRateLimiter.When(i.bindingMeta)
-	i.RetryProcessingWithDelay(err, delay)
-}
@@ -6,29 +6,36 @@ import (
 	"encoding/json"
 	e "errors"
 	"fmt"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/redhat-developer/service-binding-operator/apis"
 	bindingapi "github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/apis/spec/v1beta1"
+	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes/fakeapply"
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes/mocks"
 	"github.com/redhat-developer/service-binding-operator/pkg/converter"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/metrics"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
 	pipelinemocks "github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/mocks"
 	corev1 "k8s.io/api/core/v1"
 	v1apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/fake"
 	fakeauth "k8s.io/client-go/kubernetes/typed/authorization/v1/fake"
 	"k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
 )
 
 var _ = Describe("Context", func() {
@@ -190,6 +197,103 @@ var _ = Describe("Context", func() {
 			Entry("binding path specified", &bindingapi.BindingPath{ContainersPath: "foo.bar"}),
 		)
 
+		It("should return applications matching label selector expressions", func() {
+			ls := &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "env", Operator: metav1.LabelSelectorOpIn, Values: []string{"prod", "stage"}},
+					{Key: "tier", Operator: metav1.LabelSelectorOpDoesNotExist},
+				},
+			}
+
+			ref := bindingapi.Application{
+				Ref: bindingapi.Ref{
+					Group:   "app",
+					Version: "v1",
+					Kind:    "Foo",
+				},
+				LabelSelector: ls,
+			}
+
+			sb := bindingapi.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sb1",
+					Namespace: "ns1",
+				},
+				Spec: bindingapi.ServiceBindingSpec{
+					Application: ref,
+				},
+			}
+			gvr := &schema.GroupVersionResource{Group: "app", Version: "v1", Resource: "foos"}
+			typeLookup.EXPECT().ResourceForReferable(&ref).Return(gvr, nil)
+
+			var objs []runtime.Object
+			for name, l := range map[string]map[string]string{
+				"app1": {"env": "prod"},
+				"app2": {"env": "stage"},
+				"app3": {"env": "dev"},
+				"app4": {"env": "prod", "tier": "backend"},
+			} {
+				u := &unstructured.Unstructured{}
+				u.SetName(name)
+				u.SetNamespace(sb.Namespace)
+				u.SetGroupVersionKind(schema.GroupVersionKind{Group: "app", Version: "v1", Kind: "Foo"})
+				u.SetLabels(l)
+				objs = append(objs, u)
+			}
+
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), objs...)
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, err := Provider(client, authClient.SubjectAccessReviews(), typeLookup).Get(&sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			applications, err := ctx.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(applications).To(HaveLen(2))
+
+			var names []string
+			for _, a := range applications {
+				names = append(names, a.Resource().GetName())
+			}
+			Expect(names).To(ConsistOf("app1", "app2"))
+		})
+
+		It("should return error if label selector is invalid", func() {
+			ls := &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "env", Operator: "Foo"},
+				},
+			}
+
+			ref := bindingapi.Application{
+				Ref: bindingapi.Ref{
+					Group:   "app",
+					Version: "v1",
+					Kind:    "Foo",
+				},
+				LabelSelector: ls,
+			}
+
+			sb := bindingapi.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sb1",
+					Namespace: "ns1",
+				},
+				Spec: bindingapi.ServiceBindingSpec{
+					Application: ref,
+				},
+			}
+			gvr := &schema.GroupVersionResource{Group: "app", Version: "v1", Resource: "foos"}
+			typeLookup.EXPECT().ResourceForReferable(&ref).Return(gvr, nil)
+
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme())
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, err := Provider(client, authClient.SubjectAccessReviews(), typeLookup).Get(&sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = ctx.Applications()
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("should return error if application list returns error", func() {
 			ls := &metav1.LabelSelector{
 				MatchLabels: map[string]string{"env": "prod"},
@@ -569,6 +673,36 @@ var _ = Describe("Context", func() {
 			Expect(ctx.BindingSecretName()).To(Equal(ctx2.BindingSecretName()))
 		})
 
+		It("should be derived from binding data", func() {
+			ctx, _ := testProvider.Get(&bindingapi.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sb1",
+				},
+			})
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo2", Value: []byte("v2")})
+
+			Expect(ctx.BindingSecretName()).To(Equal("sb1-ff5d5079"))
+
+			ctx2, _ := testProvider.Get(&bindingapi.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sb2",
+				},
+			})
+			ctx2.AddBindingItem(&pipeline.BindingItem{Name: "port", Value: 5432})
+
+			Expect(ctx2.BindingSecretName()).To(Equal("sb2-572dd880"))
+
+			ctx3, _ := testProvider.Get(&bindingapi.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sb3",
+				},
+			})
+			ctx3.AddBindingItem(&pipeline.BindingItem{Name: "credentials", Value: map[string]interface{}{"user": "admin", "password": "secret"}})
+
+			Expect(ctx3.BindingSecretName()).To(Equal("sb3-af4c58c6"))
+		})
+
 		It("should be equal to existing secret if additional binding items exist", func() {
 			secretName := "foo"
 			namespace := "ns1"
@@ -705,7 +839,9 @@ var _ = Describe("Context", func() {
 			s := runtime.NewScheme()
 			Expect(bindingapi.AddToScheme(s)).NotTo(HaveOccurred())
 			Expect(corev1.AddToScheme(s)).NotTo(HaveOccurred())
-			client = fake.NewSimpleDynamicClient(s, u)
+			fakeClient := fake.NewSimpleDynamicClient(s, u)
+			fakeapply.AddReactor(fakeClient, FieldManager)
+			client = fakeClient
 			authClient := &fakeauth.FakeAuthorizationV1{}
 
 			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup).Get(sb)
@@ -819,6 +955,318 @@ var _ = Describe("Context", func() {
 			Expect(u.Object["Spec"]).To(Equal(specData))
 
 		})
+
+		It("should record events about created secret and injected workloads", func() {
+			recorder := record.NewFakeRecorder(10)
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup, WithEventRecorder(recorder)).Get(sb)
+			sb.Spec.Application = bindingapi.Application{
+				Ref: bindingapi.Ref{
+					Group:   "app",
+					Version: "v1",
+					Kind:    "Foo",
+					Name:    "app1",
+				},
+			}
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+			gvr := schema.GroupVersionResource{Group: "app", Version: "v1", Resource: "foos"}
+			typeLookup.EXPECT().ResourceForReferable(&(sb.Spec.Application)).Return(&gvr, nil)
+			u := &unstructured.Unstructured{}
+			u.SetNamespace(sb.Namespace)
+			u.SetName("app1")
+			u.SetGroupVersionKind(gvr.GroupVersion().WithKind("Foo"))
+			_, err := client.Resource(gvr).Namespace(sb.Namespace).Create(context.Background(), u, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ctx.PersistSecret()).To(Succeed())
+			apps, err := ctx.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			apps[0].Resource().Object["Spec"] = map[string]interface{}{"foo": "bar"}
+			Expect(ctx.Close()).To(Succeed())
+
+			close(recorder.Events)
+			var events []string
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+			Expect(events).To(ConsistOf(
+				"Normal SecretCreated Created binding secret "+ctx.BindingSecretName(),
+				"Normal DataCollected Binding data collected",
+				"Normal BindingInjected foos app1: Binding data injected",
+				"Normal BindingInjected Service binding sb1: Binding data injected",
+			))
+		})
+
+		It("should record events about unbound workloads once they are updated", func() {
+			sb.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+			recorder := record.NewFakeRecorder(10)
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup, WithEventRecorder(recorder)).Get(sb)
+			sb.Spec.Application = bindingapi.Application{
+				Ref: bindingapi.Ref{
+					Group:   "app",
+					Version: "v1",
+					Kind:    "Foo",
+					Name:    "app1",
+				},
+			}
+			gvr := schema.GroupVersionResource{Group: "app", Version: "v1", Resource: "foos"}
+			typeLookup.EXPECT().ResourceForReferable(&(sb.Spec.Application)).Return(&gvr, nil)
+			u := &unstructured.Unstructured{}
+			u.SetNamespace(sb.Namespace)
+			u.SetName("app1")
+			u.SetGroupVersionKind(gvr.GroupVersion().WithKind("Foo"))
+			_, err := client.Resource(gvr).Namespace(sb.Namespace).Create(context.Background(), u, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			apps, err := ctx.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			apps[0].Resource().Object["Spec"] = map[string]interface{}{"foo": "bar"}
+			Expect(recorder.Events).To(BeEmpty())
+			Expect(ctx.Close()).To(Succeed())
+
+			close(recorder.Events)
+			var events []string
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+			Expect(events).To(ConsistOf(
+				"Normal Unbound foos app1: Binding data removed",
+				"Normal Unbound Service binding sb1: Binding data removed",
+				"Normal Unbound Workloads unbound",
+			))
+		})
+
+		It("should not update the secret nor record events if binding data has not changed", func() {
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup).Get(sb)
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+			Expect(ctx.PersistSecret()).To(Succeed())
+
+			recorder := record.NewFakeRecorder(10)
+			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup, WithEventRecorder(recorder)).Get(sb)
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+			updates := testutil.ToFloat64(metrics.SecretWrites.WithLabelValues("update"))
+
+			Expect(ctx.PersistSecret()).To(Succeed())
+			Expect(testutil.ToFloat64(metrics.SecretWrites.WithLabelValues("update"))).To(Equal(updates))
+			Expect(recorder.Events).To(BeEmpty())
+		})
+
+		It("should count secret writes and binding errors", func() {
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup).Get(sb)
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+
+			creates := testutil.ToFloat64(metrics.SecretWrites.WithLabelValues("create"))
+			readErrors := testutil.ToFloat64(metrics.Errors.WithLabelValues(apis.CollectionReady, "ErrorReadingBinding"))
+
+			Expect(ctx.PersistSecret()).To(Succeed())
+			ctx.SetCondition(apis.Conditions().NotCollectionReady().Reason("ErrorReadingBinding").Msg("foo").Build())
+			ctx.SetCondition(apis.Conditions().CollectionReady().DataCollected().Build())
+
+			Expect(testutil.ToFloat64(metrics.SecretWrites.WithLabelValues("create"))).To(Equal(creates + 1))
+			Expect(testutil.ToFloat64(metrics.Errors.WithLabelValues(apis.CollectionReady, "ErrorReadingBinding"))).To(Equal(readErrors + 1))
+		})
+
+		Describe("workload status", func() {
+			var (
+				gvr  schema.GroupVersionResource
+				apps []pipeline.Application
+			)
+
+			BeforeEach(func() {
+				sb.Spec.Application = bindingapi.Application{
+					Ref: bindingapi.Ref{
+						Group:   "app",
+						Version: "v1",
+						Kind:    "Foo",
+						Name:    "app1",
+					},
+				}
+				ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+				gvr = schema.GroupVersionResource{Group: "app", Version: "v1", Resource: "foos"}
+				typeLookup.EXPECT().ResourceForReferable(&(sb.Spec.Application)).Return(&gvr, nil)
+
+				u := &unstructured.Unstructured{}
+				u.SetNamespace(sb.Namespace)
+				u.SetName("app1")
+				u.SetGeneration(3)
+				u.SetGroupVersionKind(gvr.GroupVersion().WithKind("Foo"))
+				_, err := client.Resource(gvr).Namespace(sb.Namespace).Create(context.Background(), u, metav1.CreateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+
+				apps, err = ctx.Applications()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			persistedWorkloads := func() []apis.WorkloadStatus {
+				u, err := client.Resource(bindingapi.GroupVersionResource).Namespace(sb.Namespace).Get(context.Background(), sb.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				updatedSB := bindingapi.ServiceBinding{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &updatedSB)).To(Succeed())
+				return updatedSB.Status.Workloads
+			}
+
+			It("should report containers and mount path of bound workloads", func() {
+				transitionTime := metav1.NewTime(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+				sb.Status.Workloads = []apis.WorkloadStatus{
+					{
+						Group:     "app",
+						Version:   "v1",
+						Resource:  "foos",
+						Name:      "app1",
+						Condition: metav1.Condition{Type: apis.InjectionReady, Status: metav1.ConditionTrue, Reason: "ApplicationUpdated", LastTransitionTime: transitionTime},
+					},
+				}
+				apps[0].Resource().Object["Spec"] = map[string]interface{}{"foo": "bar"}
+				apps[0].AddBoundContainer("c1", "/bindings/sb1")
+				apps[0].AddBoundContainer("c2", "/bindings/sb1")
+				apps[0].AddBoundContainer("c1", "/bindings/sb1")
+
+				Expect(ctx.Close()).To(Succeed())
+
+				workloads := persistedWorkloads()
+				Expect(workloads).To(HaveLen(1))
+				Expect(workloads[0].Name).To(Equal("app1"))
+				Expect(workloads[0].Resource).To(Equal("foos"))
+				Expect(workloads[0].ObservedGeneration).To(Equal(int64(3)))
+				Expect(workloads[0].Containers).To(Equal([]string{"c1", "c2"}))
+				Expect(workloads[0].MountPath).To(Equal("/bindings/sb1"))
+				Expect(workloads[0].Condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(workloads[0].Condition.Reason).To(Equal("ApplicationUpdated"))
+				Expect(workloads[0].Condition.LastTransitionTime.Equal(&transitionTime)).To(BeTrue())
+			})
+
+			It("should not report workloads binding data has been removed from", func() {
+				sb.SetAnnotations(map[string]string{apis.MappingAnnotationKey: "foo"})
+				sb.Status.Workloads = []apis.WorkloadStatus{
+					{
+						Group:     "app",
+						Version:   "v1",
+						Resource:  "foos",
+						Name:      "app1",
+						Condition: metav1.Condition{Type: apis.InjectionReady, Status: metav1.ConditionTrue, Reason: "ApplicationUpdated"},
+					},
+				}
+				apps[0].Resource().Object["Spec"] = map[string]interface{}{"foo": "bar"}
+
+				Expect(ctx.Close()).To(Succeed())
+
+				Expect(persistedWorkloads()).To(BeEmpty())
+			})
+
+			It("should report workloads binding data cannot be removed from", func() {
+				sb.SetAnnotations(map[string]string{apis.MappingAnnotationKey: "foo"})
+				client.(*fake.FakeDynamicClient).PrependReactor("patch", "foos", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.NewForbidden(gvr.GroupResource(), "app1", e.New("denied"))
+				})
+				apps[0].Resource().Object["Spec"] = map[string]interface{}{"foo": "bar"}
+
+				Expect(ctx.Close()).NotTo(Succeed())
+
+				workloads := persistedWorkloads()
+				Expect(workloads).To(HaveLen(1))
+				Expect(workloads[0].Condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(workloads[0].Condition.Reason).To(Equal("ApplicationUpdateError"))
+			})
+
+			It("should report workloads binding data cannot be injected into", func() {
+				err := e.New("no containers found")
+				apps[0].SetError(err)
+				ctx.Error(err)
+
+				Expect(ctx.Close()).To(Succeed())
+
+				workloads := persistedWorkloads()
+				Expect(workloads).To(HaveLen(1))
+				Expect(workloads[0].Condition.Type).To(Equal(apis.InjectionReady))
+				Expect(workloads[0].Condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(workloads[0].Condition.Reason).To(Equal("Error"))
+				Expect(workloads[0].Condition.Message).To(Equal(err.Error()))
+			})
+
+			It("should report workloads which failed to be updated", func() {
+				client.(*fake.FakeDynamicClient).PrependReactor("patch", "foos", func(action testing.Action) (bool, runtime.Object, error) {
+					return true, nil, errors.NewForbidden(gvr.GroupResource(), "app1", e.New("denied"))
+				})
+				apps[0].Resource().Object["Spec"] = map[string]interface{}{"foo": "bar"}
+
+				Expect(ctx.Close()).NotTo(Succeed())
+
+				workloads := persistedWorkloads()
+				Expect(workloads).To(HaveLen(1))
+				Expect(workloads[0].Condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(workloads[0].Condition.Reason).To(Equal("ApplicationUpdateError"))
+			})
+
+			It("should aggregate failures of workloads when processed on a best-effort basis", func() {
+				ctx.(*bindingImpl).failurePolicy = apis.FailurePolicyBestEffort
+				err := e.New("no containers found")
+				apps[0].SetError(err)
+				apps[0].Resource().Object["Spec"] = map[string]interface{}{"foo": "bar"}
+
+				err = ctx.Close()
+				Expect(err).To(MatchError("1 of 1 workloads not bound: foos app1: no containers found"))
+
+				u, err := client.Resource(bindingapi.GroupVersionResource).Namespace(sb.Namespace).Get(context.Background(), sb.Name, metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				updatedSB := bindingapi.ServiceBinding{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &updatedSB)).To(Succeed())
+				for _, t := range []string{apis.BindingReady, apis.InjectionReady} {
+					cnd := meta.FindStatusCondition(updatedSB.Status.Conditions, t)
+					Expect(cnd).NotTo(BeNil())
+					Expect(cnd.Status).To(Equal(metav1.ConditionFalse))
+					Expect(cnd.Reason).To(Equal(apis.WorkloadsNotBoundReason))
+				}
+				Expect(updatedSB.Status.Workloads).To(HaveLen(1))
+				Expect(updatedSB.Status.Workloads[0].Condition.Status).To(Equal(metav1.ConditionFalse))
+
+				u, err = client.Resource(gvr).Namespace(sb.Namespace).Get(context.Background(), "app1", metav1.GetOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(u.Object).NotTo(HaveKey("Spec"))
+			})
+		})
+		It("should return conflict without persisting conditions if application has been modified meanwhile", func() {
+			sb.Spec.Application = bindingapi.Application{
+				Ref: bindingapi.Ref{
+					Group:   "app",
+					Version: "v1",
+					Kind:    "Foo",
+					Name:    "app1",
+				},
+			}
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+
+			gvr := schema.GroupVersionResource{Group: "app", Version: "v1", Resource: "foos"}
+			typeLookup.EXPECT().ResourceForReferable(&(sb.Spec.Application)).Return(&gvr, nil)
+
+			u := &unstructured.Unstructured{}
+			u.SetNamespace(sb.Namespace)
+			u.SetName("app1")
+			u.SetGroupVersionKind(gvr.GroupVersion().WithKind("Foo"))
+
+			_, err := client.Resource(gvr).Namespace(sb.Namespace).Create(context.Background(), u, metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			client.(*fake.FakeDynamicClient).PrependReactor("patch", "foos", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewConflict(gvr.GroupResource(), "app1", e.New("the object has been modified"))
+			})
+
+			apps, err := ctx.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			apps[0].Resource().Object["Spec"] = map[string]interface{}{"foo": "bar"}
+
+			err = ctx.Close()
+			Expect(errors.IsConflict(err)).To(BeTrue())
+
+			u, err = client.Resource(bindingapi.GroupVersionResource).Namespace(sb.Namespace).Get(context.Background(), sb.Name, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			updatedSB := bindingapi.ServiceBinding{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &updatedSB)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(updatedSB.Status.Conditions).To(BeEmpty())
+		})
+
 		It("should not update service binding if its uid is unset", func() {
 			sb.UID = ""
 			sb.Name = "sb2"
@@ -863,6 +1311,64 @@ var _ = Describe("Context", func() {
 			Expect(u.Object["Spec"]).To(Equal(specData))
 
 		})
+
+		bindingPatches := func() (status []string, metadata []string) {
+			for _, action := range client.(*fake.FakeDynamicClient).Actions() {
+				patch, ok := action.(testing.PatchAction)
+				if !ok || patch.GetResource() != bindingapi.GroupVersionResource {
+					continue
+				}
+				if patch.GetSubresource() == "status" {
+					status = append(status, string(patch.GetPatch()))
+				} else {
+					metadata = append(metadata, string(patch.GetPatch()))
+				}
+			}
+			return
+		}
+
+		It("should patch only binding status if metadata are unchanged", func() {
+			ctx.SetCondition(apis.Conditions().NotCollectionReady().ServiceNotFound().Msg("err1").Build())
+			ctx.Error(e.New("err1"))
+
+			Expect(ctx.Close()).To(Succeed())
+
+			status, metadata := bindingPatches()
+			Expect(status).To(HaveLen(1))
+			Expect(status[0]).To(ContainSubstring(`"reason":"ServiceNotFound"`))
+			Expect(metadata).To(BeEmpty())
+			for _, action := range client.(*fake.FakeDynamicClient).Actions() {
+				Expect(action.GetVerb()).NotTo(Equal("update"))
+			}
+		})
+
+		It("should not write binding if unchanged", func() {
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+			Expect(ctx.Close()).To(Succeed())
+			status, _ := bindingPatches()
+			Expect(status).To(HaveLen(1))
+
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup).Get(sb)
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+			Expect(ctx.Close()).To(Succeed())
+
+			status, metadata := bindingPatches()
+			Expect(status).To(HaveLen(1))
+			Expect(metadata).To(BeEmpty())
+		})
+
+		It("should patch binding metadata if mapping annotation is removed", func() {
+			sb.SetAnnotations(map[string]string{apis.MappingAnnotationKey: "foo", "bar": "baz"})
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup).Get(sb)
+
+			Expect(ctx.CleanAnnotations()).To(BeTrue())
+			Expect(ctx.Close()).To(Succeed())
+
+			_, metadata := bindingPatches()
+			Expect(metadata).To(Equal([]string{`{"metadata":{"annotations":{"` + apis.MappingAnnotationKey + `":null}}}`}))
+		})
 	})
 
 	Describe("Persist Secret", func() {
@@ -945,10 +1451,83 @@ var _ = Describe("Context", func() {
 			intermediateSecret := &corev1.Secret{}
 			err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, intermediateSecret)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(intermediateSecret.StringData).To(HaveLen(3))
-			Expect(intermediateSecret.StringData).Should(HaveKeyWithValue("foo1", "val1"))
-			Expect(intermediateSecret.StringData).Should(HaveKeyWithValue("foo2", "val2"))
-			Expect(intermediateSecret.StringData).Should(HaveKeyWithValue("foo3", "val3"))
+			Expect(intermediateSecret.Data).To(HaveLen(3))
+			Expect(intermediateSecret.Data).Should(HaveKeyWithValue("foo1", []byte("val1")))
+			Expect(intermediateSecret.Data).Should(HaveKeyWithValue("foo2", []byte("val2")))
+			Expect(intermediateSecret.Data).Should(HaveKeyWithValue("foo3", []byte("val3")))
+		})
+
+		It("should read secrets from the resource cache and write them with the client", func() {
+			cached := &unstructured.Unstructured{}
+			cached.SetAPIVersion("v1")
+			cached.SetKind("Secret")
+			cached.SetNamespace("ns1")
+			cached.SetName("cached")
+			cacheClient := fake.NewSimpleDynamicClient(runtime.NewScheme(), cached)
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup, WithResourceCache(&resourceCache{client: cacheClient})).Get(sb)
+
+			u, err := ctx.ReadSecret("ns1", "cached")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(u.GetName()).To(Equal("cached"))
+
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "bar"})
+			Expect(ctx.PersistSecret()).To(Succeed())
+			_, err = client.Resource(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}).Namespace("ns1").Get(context.Background(), sb.Status.Secret, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should read workloads with the client rather than from the resource cache", func() {
+			gvr := schema.GroupVersionResource{Group: "app", Version: "v1", Resource: "foos"}
+			workload := func(state string) *unstructured.Unstructured {
+				u := &unstructured.Unstructured{}
+				u.SetGroupVersionKind(gvr.GroupVersion().WithKind("Foo"))
+				u.SetNamespace(sb.Namespace)
+				u.SetName("app1")
+				u.SetLabels(map[string]string{"state": state})
+				return u
+			}
+			cacheClient := fake.NewSimpleDynamicClient(runtime.NewScheme(), workload("stale"))
+			_, err := client.Resource(gvr).Namespace(sb.Namespace).Create(context.Background(), workload("live"), metav1.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup, WithResourceCache(&resourceCache{client: cacheClient})).Get(sb)
+			sb.Spec.Application = bindingapi.Application{
+				Ref: bindingapi.Ref{
+					Group:   "app",
+					Version: "v1",
+					Kind:    "Foo",
+					Name:    "app1",
+				},
+			}
+			typeLookup.EXPECT().ResourceForReferable(&(sb.Spec.Application)).Return(&gvr, nil)
+
+			apps, err := ctx.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(apps).To(HaveLen(1))
+			Expect(apps[0].Resource().GetLabels()).To(Equal(map[string]string{"state": "live"}))
+		})
+
+		It("should write typed binding items byte-for-byte", func() {
+			binary := []byte{0xde, 0xad, 0xbe, 0xef}
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "keystore", Value: binary})
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "port", Value: int64(5432)})
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "nodes", Value: []interface{}{map[string]interface{}{"host": "a"}}})
+
+			err := ctx.PersistSecret()
+			Expect(err).NotTo(HaveOccurred())
+
+			u, err := ctx.ReadSecret(sb.Namespace, sb.Status.Secret)
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, secret)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Data).To(Equal(map[string][]byte{
+				"keystore": binary,
+				"port":     []byte("5432"),
+				"nodes":    []byte(`[{"host":"a"}]`),
+			}))
 		})
 	})
 
@@ -1203,4 +1782,290 @@ var _ = Describe("Context", func() {
 			Expect(sb.GetAnnotations()).To(Equal(map[string]string{"spam": "eggs"}))
 		})
 	})
+
+	DescribeTable("should return restart policy", func(policy apis.RestartPolicy, expected apis.RestartPolicy) {
+		sb := &bindingapi.ServiceBinding{
+			Spec: bindingapi.ServiceBindingSpec{
+				RestartPolicy: policy,
+			},
+		}
+		ctx, err := Provider(nil, nil, nil).Get(sb)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ctx.RestartPolicy()).To(Equal(expected))
+	},
+		Entry("never by default", apis.RestartPolicy(""), apis.RestartPolicyNever),
+		Entry("never", apis.RestartPolicyNever, apis.RestartPolicyNever),
+		Entry("on change", apis.RestartPolicyOnChange, apis.RestartPolicyOnChange),
+	)
+
+	It("should change binding data hash when binding items change", func() {
+		sb := &bindingapi.ServiceBinding{}
+		ctx, err := Provider(nil, nil, nil).Get(sb)
+		Expect(err).NotTo(HaveOccurred())
+		ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+		hash := ctx.BindingDataHash()
+
+		ctx2, err := Provider(nil, nil, nil).Get(sb)
+		Expect(err).NotTo(HaveOccurred())
+		ctx2.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+		Expect(ctx2.BindingDataHash()).To(Equal(hash))
+		ctx2.AddBindingItem(&pipeline.BindingItem{Name: "bar", Value: "v2"})
+		Expect(ctx2.BindingDataHash()).NotTo(Equal(hash))
+	})
+
+	It("should not confuse binding item names and values in binding data hash", func() {
+		sb := &bindingapi.ServiceBinding{}
+		ctx, err := Provider(nil, nil, nil).Get(sb)
+		Expect(err).NotTo(HaveOccurred())
+		ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "bar"})
+
+		ctx2, err := Provider(nil, nil, nil).Get(sb)
+		Expect(err).NotTo(HaveOccurred())
+		ctx2.AddBindingItem(&pipeline.BindingItem{Name: "foob", Value: "ar"})
+		Expect(ctx2.BindingDataHash()).NotTo(Equal(ctx.BindingDataHash()))
+	})
+
+	Describe("Resource tracking", func() {
+		var (
+			sb      *bindingapi.ServiceBinding
+			client  *fake.FakeDynamicClient
+			tracker *fakeTracker
+		)
+
+		BeforeEach(func() {
+			sb = &bindingapi.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sb1",
+					Namespace: "ns1",
+				},
+				Spec: bindingapi.ServiceBindingSpec{
+					Services: []bindingapi.Service{
+						{
+							NamespacedRef: bindingapi.NamespacedRef{
+								Ref: bindingapi.Ref{Group: "foo", Version: "v1", Kind: "Bar", Name: "s0"},
+							},
+						},
+					},
+				},
+			}
+			sb.SetGroupVersionKind(bindingapi.GroupVersionKind)
+			u, err := converter.ToUnstructured(sb)
+			Expect(err).NotTo(HaveOccurred())
+			service := &unstructured.Unstructured{}
+			service.SetGroupVersionKind(schema.GroupVersionKind{Group: "foo", Version: "v1", Kind: "Bar"})
+			service.SetName("s0")
+			service.SetNamespace(sb.Namespace)
+			s := scheme(service)
+			Expect(bindingapi.AddToScheme(s)).NotTo(HaveOccurred())
+			client = fake.NewSimpleDynamicClient(s, u, service)
+			tracker = &fakeTracker{}
+		})
+
+		It("should track services and referred secrets and config maps on close", func() {
+			gvr := &schema.GroupVersionResource{Group: "foo", Version: "v1", Resource: "bars"}
+			typeLookup.EXPECT().ResourceForReferable(gomock.Any()).Return(gvr, nil)
+			typeLookup.EXPECT().ResourceForKind(gomock.Any()).Return(gvr, nil).AnyTimes()
+			authClient := &fakeauth.FakeAuthorizationV1{}
+
+			ctx, err := Provider(client, authClient.SubjectAccessReviews(), typeLookup, WithResourceTracker(tracker)).Get(sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = ctx.Services()
+			Expect(err).NotTo(HaveOccurred())
+			_, _ = ctx.ReadSecret("ns1", "secret1")
+			_, _ = ctx.ReadConfigMap("ns1", "cm1")
+			Expect(tracker.calls).To(BeEmpty())
+
+			Expect(ctx.Close()).To(Succeed())
+			Expect(tracker.calls).To(HaveLen(1))
+			Expect(tracker.calls[0].binding).To(Equal(types.NamespacedName{Namespace: "ns1", Name: "sb1"}))
+			Expect(tracker.calls[0].resources).To(ConsistOf(
+				pipeline.ResourceReference{GroupVersionResource: *gvr, Namespace: "ns1", Name: "s0"},
+				pipeline.ResourceReference{GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, Namespace: "ns1", Name: "secret1"},
+				pipeline.ResourceReference{GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, Namespace: "ns1", Name: "cm1"},
+			))
+		})
+
+		It("should track workloads selected by labels", func() {
+			sb.Spec.Application = bindingapi.Application{
+				Ref:           bindingapi.Ref{Group: "app", Version: "v1", Kind: "Foo"},
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			}
+			gvr := &schema.GroupVersionResource{Group: "app", Version: "v1", Resource: "foos"}
+			typeLookup.EXPECT().ResourceForReferable(&sb.Spec.Application).Return(gvr, nil)
+			app := &unstructured.Unstructured{}
+			app.SetGroupVersionKind(schema.GroupVersionKind{Group: "app", Version: "v1", Kind: "Foo"})
+			app.SetName("app1")
+			app.SetNamespace(sb.Namespace)
+			app.SetLabels(map[string]string{"env": "prod"})
+			client = fake.NewSimpleDynamicClient(scheme(app), app)
+			authClient := &fakeauth.FakeAuthorizationV1{}
+
+			ctx, err := Provider(client, authClient.SubjectAccessReviews(), typeLookup, WithResourceTracker(tracker)).Get(sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = ctx.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			ctx.Error(e.New("foo"))
+
+			Expect(ctx.Close()).To(Succeed())
+			Expect(tracker.calls).To(HaveLen(1))
+			Expect(tracker.calls[0].resources).To(HaveLen(1))
+			ref := tracker.calls[0].resources[0]
+			Expect(ref.GroupVersionResource).To(Equal(*gvr))
+			Expect(ref.Namespace).To(Equal(sb.Namespace))
+			Expect(ref.Selector.String()).To(Equal("env=prod"))
+			Expect(ctx.FlowStatus().Retry).To(BeFalse())
+		})
+
+		It("should reprocess binding periodically if selected workloads cannot be watched", func() {
+			sb.Spec.Application = bindingapi.Application{
+				Ref:           bindingapi.Ref{Group: "app", Version: "v1", Kind: "Foo"},
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			}
+			gvr := &schema.GroupVersionResource{Group: "app", Version: "v1", Resource: "foos"}
+			typeLookup.EXPECT().ResourceForReferable(&sb.Spec.Application).Return(gvr, nil)
+			app := &unstructured.Unstructured{}
+			app.SetGroupVersionKind(schema.GroupVersionKind{Group: "app", Version: "v1", Kind: "Foo"})
+			app.SetName("app1")
+			app.SetNamespace(sb.Namespace)
+			app.SetLabels(map[string]string{"env": "prod"})
+			client = fake.NewSimpleDynamicClient(scheme(app), app)
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			tracker.unwatchable = true
+
+			ctx, err := Provider(client, authClient.SubjectAccessReviews(), typeLookup, WithResourceTracker(tracker)).Get(sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = ctx.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			err = e.New("foo")
+			ctx.Error(err)
+
+			Expect(ctx.Close()).To(Succeed())
+			Expect(ctx.FlowStatus()).To(Equal(pipeline.FlowStatus{Retry: true, Stop: true, Err: err, Delay: LabelSelectionResyncPeriod}))
+		})
+
+		It("should not reprocess binding periodically if only referred resources cannot be watched", func() {
+			tracker.unwatchable = true
+			authClient := &fakeauth.FakeAuthorizationV1{}
+
+			ctx, err := Provider(client, authClient.SubjectAccessReviews(), typeLookup, WithResourceTracker(tracker)).Get(sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _ = ctx.ReadSecret("ns1", "secret1")
+			Expect(ctx.Close()).To(Succeed())
+			Expect(ctx.FlowStatus().Retry).To(BeFalse())
+		})
+
+		It("should stop tracking removed binding", func() {
+			sb.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+			authClient := &fakeauth.FakeAuthorizationV1{}
+
+			ctx, err := Provider(client, authClient.SubjectAccessReviews(), typeLookup, WithResourceTracker(tracker)).Get(sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _ = ctx.ReadSecret("ns1", "secret1")
+			Expect(ctx.Close()).To(Succeed())
+			Expect(tracker.calls).To(HaveLen(1))
+			Expect(tracker.calls[0].resources).To(BeNil())
+		})
+	})
+
+	Describe("Cross-namespace references", func() {
+		var (
+			sb         *bindingapi.ServiceBinding
+			authClient *fakeauth.FakeAuthorizationV1
+			ref        apis.CrossNamespaceReference
+		)
+
+		BeforeEach(func() {
+			sb = &bindingapi.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sb1",
+					Namespace: "ns1",
+				},
+				Status: bindingapi.ServiceBindingStatus{
+					CrossNamespaceReferences: []apis.CrossNamespaceReference{{Kind: "Secret", Namespace: "old", Name: "old"}},
+				},
+			}
+			authClient = &fakeauth.FakeAuthorizationV1{}
+			ref = apis.CrossNamespaceReference{Kind: "Secret", Namespace: "credentials", Name: "db"}
+		})
+
+		It("should not be allowed by default", func() {
+			ctx, err := Provider(fake.NewSimpleDynamicClient(runtime.NewScheme()), authClient.SubjectAccessReviews(), typeLookup).Get(sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ctx.AuthorizeCrossNamespaceReference(ref)).To(MatchError("cross-namespace reference to secret db in namespace credentials is not allowed"))
+			Expect(sb.Status.CrossNamespaceReferences).To(BeEmpty())
+		})
+
+		It("should not be allowed for namespaces not in allow list", func() {
+			ctx, err := Provider(fake.NewSimpleDynamicClient(runtime.NewScheme()), authClient.SubjectAccessReviews(), typeLookup,
+				WithCrossNamespaceAllowList([]string{"shared", ""})).Get(sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ctx.AuthorizeCrossNamespaceReference(ref)).To(HaveOccurred())
+		})
+
+		DescribeTable("should be recorded in status when allowed", func(allowList []string) {
+			ctx, err := Provider(fake.NewSimpleDynamicClient(runtime.NewScheme()), authClient.SubjectAccessReviews(), typeLookup,
+				WithCrossNamespaceAllowList(allowList)).Get(sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			cm := apis.CrossNamespaceReference{Kind: "ConfigMap", Namespace: "credentials", Name: "db"}
+			Expect(ctx.AuthorizeCrossNamespaceReference(ref)).To(Succeed())
+			Expect(ctx.AuthorizeCrossNamespaceReference(cm)).To(Succeed())
+			Expect(ctx.AuthorizeCrossNamespaceReference(ref)).To(Succeed())
+			Expect(sb.Status.CrossNamespaceReferences).To(Equal([]apis.CrossNamespaceReference{ref, cm}))
+		},
+			Entry("listed namespace", []string{"shared", " credentials"}),
+			Entry("any namespace", []string{"*"}),
+		)
+
+		It("should be recorded in spec binding status when allowed", func() {
+			specSB := &v1beta1.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sb1",
+					Namespace: "ns1",
+				},
+			}
+			ctx, err := SpecProvider(fake.NewSimpleDynamicClient(runtime.NewScheme()), authClient.SubjectAccessReviews(), typeLookup,
+				WithCrossNamespaceAllowList([]string{"credentials"})).Get(specSB)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(ctx.AuthorizeCrossNamespaceReference(ref)).To(Succeed())
+			Expect(specSB.Status.CrossNamespaceReferences).To(Equal([]apis.CrossNamespaceReference{ref}))
+		})
+	})
 })
+
+type trackCall struct {
+	binding   types.NamespacedName
+	resources []pipeline.ResourceReference
+}
+
+type fakeTracker struct {
+	calls []trackCall
+
+	// if set, none of the tracked resources can be watched
+	unwatchable bool
+}
+
+func (f *fakeTracker) Track(binding types.NamespacedName, resources []pipeline.ResourceReference) []pipeline.ResourceReference {
+	f.calls = append(f.calls, trackCall{binding: binding, resources: resources})
+	if f.unwatchable {
+		return resources
+	}
+	return nil
+}
+
+// resourceCache serves reads with a client of its own
+type resourceCache struct {
+	client dynamic.Interface
+}
+
+func (c *resourceCache) Client(client dynamic.Interface) dynamic.Interface {
+	return c.client
+}
@@ -4,9 +4,18 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	bindingapi "github.com/redhat-developer/service-binding-operator/apis/binding/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/binding"
+	"github.com/redhat-developer/service-binding-operator/pkg/converter"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/testing"
+	ctrlfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/golang/mock/gomock"
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
@@ -130,4 +139,140 @@ var _ = Describe("CRD", func() {
 		Expect(crd.IsBindable()).To(BeFalse())
 	})
 
+	Describe("binding definition templates", func() {
+		var sources *binding.DefinitionSourceRegistry
+
+		BeforeEach(func() {
+			sources = binding.DefinitionSources
+			scheme := runtime.NewScheme()
+			Expect(bindingapi.AddToScheme(scheme)).NotTo(HaveOccurred())
+			templates := ctrlfake.NewClientBuilder().WithScheme(scheme).WithObjects(&bindingapi.BindingDefinitionTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "t1"},
+				Spec: bindingapi.BindingDefinitionTemplateSpec{
+					Group:       "app1.example.org",
+					Kind:        "BackingService",
+					Versions:    []string{"v1"},
+					Definitions: map[string]string{"service.binding/host": "path={.spec.host}"},
+				},
+			}).Build()
+			binding.DefinitionSources = binding.NewDefinitionSourceRegistry()
+			binding.DefinitionSources.Register(binding.TemplateSourcePrecedence, binding.NewTemplateSource(templates))
+		})
+
+		AfterEach(func() {
+			binding.DefinitionSources = sources
+		})
+
+		DescribeTable("should be bindable if a template matches the CRD kind", func(version string, expected bool) {
+			u := &unstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"group": "app1.example.org",
+					"names": map[string]interface{}{
+						"kind": "BackingService",
+					},
+				},
+			}}
+			gvr := schema.GroupVersionResource{Group: "app1.example.org", Version: version, Resource: "backingservices"}
+			crd := &customResourceDefinition{resource: u, client: client, serviceGVR: &gvr}
+			Expect(crd.IsBindable()).To(Equal(expected))
+		},
+			Entry("matching version", "v1", true),
+			Entry("other version", "v1alpha1", false),
+		)
+	})
+
+	Describe("Descriptor", func() {
+		var (
+			csv = func(name string, namespace string, owned ...olmv1alpha1.CRDDescription) *unstructured.Unstructured {
+				obj := &olmv1alpha1.ClusterServiceVersion{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: olmv1alpha1.SchemeGroupVersion.String(),
+						Kind:       "ClusterServiceVersion",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+					Spec: olmv1alpha1.ClusterServiceVersionSpec{
+						CustomResourceDefinitions: olmv1alpha1.CustomResourceDefinitions{
+							Owned: owned,
+						},
+					},
+				}
+				u, err := converter.ToUnstructured(obj)
+				Expect(err).NotTo(HaveOccurred())
+				return u
+			}
+			crdResource = func(name string) *unstructured.Unstructured {
+				u := &unstructured.Unstructured{}
+				u.SetName(name)
+				return u
+			}
+			gvr = &schema.GroupVersionResource{Group: "app1.example.org", Version: "v1alpha1", Resource: "backingservices"}
+		)
+
+		It("should return descriptor of owning CSV", func() {
+			descr := olmv1alpha1.CRDDescription{
+				Name:    "backingservices.app1.example.org",
+				Version: "v1alpha1",
+				Kind:    "BackingService",
+				StatusDescriptors: []olmv1alpha1.StatusDescriptor{
+					{Path: "foo", XDescriptors: []string{"service.binding"}},
+				},
+			}
+			other := olmv1alpha1.CRDDescription{
+				Name:    "backingservices.app1.example.org",
+				Version: "v1",
+				Kind:    "BackingService",
+			}
+			sch := runtime.NewScheme()
+			Expect(olmv1alpha1.AddToScheme(sch)).NotTo(HaveOccurred())
+			client = fake.NewSimpleDynamicClient(sch, csv("csv1", "ns1", other, descr), csv("csv2", "ns2", descr))
+			crd := &customResourceDefinition{resource: crdResource(descr.Name), client: client, ns: "ns1", serviceGVR: gvr}
+
+			result, err := crd.Descriptor()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal((*pipeline.CRDDescription)(&descr)))
+			Expect(crd.IsBindable()).To(BeTrue())
+		})
+
+		It("should return nil if no CSV owns the CRD", func() {
+			descr := olmv1alpha1.CRDDescription{
+				Name:    "foos.app1.example.org",
+				Version: "v1alpha1",
+				Kind:    "Foo",
+			}
+			sch := runtime.NewScheme()
+			Expect(olmv1alpha1.AddToScheme(sch)).NotTo(HaveOccurred())
+			client = fake.NewSimpleDynamicClient(sch, csv("csv1", "ns1", descr))
+			crd := &customResourceDefinition{resource: crdResource("backingservices.app1.example.org"), client: client, ns: "ns1", serviceGVR: gvr}
+
+			result, err := crd.Descriptor()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeNil())
+			Expect(crd.IsBindable()).To(BeFalse())
+		})
+
+		It("should return nil if OLM is not installed", func() {
+			client.PrependReactor("list", "clusterserviceversions", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewNotFound(csvGVR.GroupResource(), "")
+			})
+			crd := &customResourceDefinition{resource: crdResource("backingservices.app1.example.org"), client: client, ns: "ns1", serviceGVR: gvr}
+
+			result, err := crd.Descriptor()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(BeNil())
+		})
+
+		It("should return error if listing CSVs fails", func() {
+			client.PrependReactor("list", "clusterserviceversions", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.NewForbidden(csvGVR.GroupResource(), "", nil)
+			})
+			crd := &customResourceDefinition{resource: crdResource("backingservices.app1.example.org"), client: client, ns: "ns1", serviceGVR: gvr}
+
+			_, err := crd.Descriptor()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 })
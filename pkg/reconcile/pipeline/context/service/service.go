@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strings"
 
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/pkg/binding"
 	"github.com/redhat-developer/service-binding-operator/pkg/binding/registry"
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes"
@@ -14,15 +15,23 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/util/jsonpath"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 var _ pipeline.Service = &service{}
 
+var serviceLog = ctrl.Log.WithName("service")
+
 type CrdReader func(gvk *schema.GroupVersionResource) (*unstructured.Unstructured, error)
 
+// RequestContext returns the context of client calls made by services, e.g. carrying the span of the current handler
+type RequestContext func() context.Context
+
 type Builder interface {
 	WithClient(client dynamic.Interface) Builder
 	WithCrdReader(reader CrdReader) Builder
@@ -44,6 +53,19 @@ func CrdReaderOption(reader CrdReader) buildOption {
 	}
 }
 
+func RequestContextOption(ctx RequestContext) buildOption {
+	return func(s *service) {
+		s.requestCtx = ctx
+	}
+}
+
+func requestContext(ctx RequestContext) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx()
+}
+
 type builder struct {
 	client                dynamic.Interface
 	typeLookup            kubernetes.K8STypeLookup
@@ -88,20 +110,20 @@ func (b *builder) Build(content *unstructured.Unstructured, options ...buildOpti
 		o(s)
 	}
 	if s.CrdReader == nil {
-		if b.crdReader == nil {
-			b.crdReader = func(gvr *schema.GroupVersionResource) (*unstructured.Unstructured, error) {
-				var err error
-				var u *unstructured.Unstructured
-				for _, crd := range crdGVRs {
-					u, err = b.client.Resource(crd).Get(context.Background(), gvr.GroupResource().String(), metav1.GetOptions{})
-					if err == nil {
-						return u, nil
-					}
+		s.CrdReader = b.crdReader
+	}
+	if s.CrdReader == nil {
+		s.CrdReader = func(gvr *schema.GroupVersionResource) (*unstructured.Unstructured, error) {
+			var err error
+			var u *unstructured.Unstructured
+			for _, crd := range crdGVRs {
+				u, err = b.client.Resource(crd).Get(requestContext(s.requestCtx), gvr.GroupResource().String(), metav1.GetOptions{})
+				if err == nil {
+					return u, nil
 				}
-				return nil, err
 			}
+			return nil, err
 		}
-		s.CrdReader = b.crdReader
 	}
 	return s, nil
 }
@@ -119,12 +141,9 @@ var crdGVRs = []schema.GroupVersionResource{
 	},
 }
 
-var bindableResourceGVRs = []schema.GroupVersionResource{
-	{Group: "", Version: "v1", Resource: "configmaps"},
-	{Group: "", Version: "v1", Resource: "secrets"},
-	{Group: "", Version: "v1", Resource: "services"},
-	{Group: "route.openshift.io", Version: "v1", Resource: "routes"},
-}
+// MaxOwnershipDepth limits how deep ownership chains are followed when looking up owned resources,
+// 1 for resources owned directly by the service, 2 for services owned by a statefulset owned by the service
+var MaxOwnershipDepth = 1
 
 type service struct {
 	client               dynamic.Interface
@@ -137,32 +156,74 @@ type service struct {
 	lookForOwnedResources bool
 	bindingDefinitions    []binding.Definition
 	id                    *string
+	requestCtx            RequestContext
 }
 
 func (s *service) OwnedResources() ([]*unstructured.Unstructured, error) {
-	uid := s.Resource().GetUID()
 	var result []*unstructured.Unstructured
 	if !s.lookForOwnedResources {
 		return result, nil
 	}
-	for _, gvr := range bindableResourceGVRs {
-		list, err := s.client.Resource(gvr).Namespace(s.namespace).List(context.Background(), metav1.ListOptions{})
-		if err != nil {
-			if errors.IsNotFound(err) {
-				continue
+	kinds := binding.OwnedKinds.Kinds()
+	// resources listed once per kind when owned resources are not indexed, keyed by owner UID
+	listed := make(map[schema.GroupVersionResource]map[types.UID][]*unstructured.Unstructured)
+	visited := map[types.UID]bool{s.Resource().GetUID(): true}
+	owners := []types.UID{s.Resource().GetUID()}
+	for depth := 0; depth < MaxOwnershipDepth && len(owners) > 0; depth++ {
+		var next []types.UID
+		for _, owner := range owners {
+			for _, kind := range kinds {
+				owned, err := s.ownedBy(kind.GroupVersionResource, owner, listed)
+				if err != nil {
+					return nil, err
+				}
+				for _, item := range owned {
+					if uid := item.GetUID(); uid != "" {
+						if visited[uid] {
+							continue
+						}
+						visited[uid] = true
+						next = append(next, uid)
+					}
+					if kind.Bindable() {
+						result = append(result, item)
+					}
+				}
 			}
+		}
+		owners = next
+	}
+	return result, nil
+}
+
+func (s *service) ownedBy(gvr schema.GroupVersionResource, owner types.UID, listed map[schema.GroupVersionResource]map[types.UID][]*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	if index, ok := s.client.(pipeline.OwnerIndex); ok {
+		owned, indexed, err := index.OwnedBy(requestContext(s.requestCtx), gvr, s.namespace, owner)
+		if err != nil || indexed {
+			return owned, err
+		}
+	}
+	byOwner, ok := listed[gvr]
+	if !ok {
+		byOwner = make(map[types.UID][]*unstructured.Unstructured)
+		list, err := s.client.Resource(gvr).Namespace(s.namespace).List(requestContext(s.requestCtx), metav1.ListOptions{})
+		if errors.IsForbidden(err) {
+			// owned resources the operator is not allowed to read, e.g. kinds of profiles, are not detected
+			serviceLog.Info("Not allowed to list owned resources, skipping them", "resource", gvr.GroupResource(), "namespace", s.namespace)
+		} else if err != nil && !errors.IsNotFound(err) {
 			return nil, err
 		}
-		for i := range list.Items {
-			item := list.Items[i]
-			for _, ownerRef := range item.GetOwnerReferences() {
-				if reflect.DeepEqual(ownerRef.UID, uid) {
-					result = append(result, &item)
+		if list != nil {
+			for i := range list.Items {
+				item := &list.Items[i]
+				for _, ownerRef := range item.GetOwnerReferences() {
+					byOwner[ownerRef.UID] = append(byOwner[ownerRef.UID], item)
 				}
 			}
 		}
+		listed[gvr] = byOwner
 	}
-	return result, nil
+	return byOwner[owner], nil
 }
 
 func (s *service) Id() *string {
@@ -187,7 +248,7 @@ func (s *service) CustomResourceDefinition() (pipeline.CRD, error) {
 		if err != nil {
 			return nil, err
 		}
-		s.crd = &customResourceDefinition{resource: u, client: s.client, ns: s.namespace, serviceGVR: s.groupVersionResource}
+		s.crd = &customResourceDefinition{resource: u, client: s.client, ns: s.namespace, serviceGVR: s.groupVersionResource, requestCtx: s.requestCtx}
 		return s.crd, err
 	}
 	return s.crd, nil
@@ -209,11 +270,60 @@ func (s *service) IsBindable() (bool, error) {
 	return crd.IsBindable()
 }
 
+var csvGVR = olmv1alpha1.SchemeGroupVersion.WithResource("clusterserviceversions")
+
 type customResourceDefinition struct {
-	resource   *unstructured.Unstructured
-	serviceGVR *schema.GroupVersionResource
-	client     dynamic.Interface
-	ns         string
+	resource         *unstructured.Unstructured
+	serviceGVR       *schema.GroupVersionResource
+	client           dynamic.Interface
+	ns               string
+	descriptor       *pipeline.CRDDescription
+	descriptorLookup bool
+	requestCtx       RequestContext
+}
+
+func (c *customResourceDefinition) Descriptor() (*pipeline.CRDDescription, error) {
+	if c.descriptorLookup {
+		return c.descriptor, nil
+	}
+	opts := metav1.ListOptions{}
+	if c.ns == "" {
+		// when looking through all namespaces skip CSV copies made by OLM for operators watching all namespaces
+		opts.LabelSelector = "!olm.copiedFrom"
+	}
+	csvs, err := c.client.Resource(csvGVR).Namespace(c.ns).List(requestContext(c.requestCtx), opts)
+	if err != nil {
+		// OLM is not installed on the cluster
+		if errors.IsNotFound(err) {
+			c.descriptorLookup = true
+			return nil, nil
+		}
+		return nil, err
+	}
+	for i := range csvs.Items {
+		ownedCRDs, found, err := unstructured.NestedSlice(csvs.Items[i].Object, "spec", "customresourcedefinitions", "owned")
+		if err != nil || !found {
+			continue
+		}
+		for _, owned := range ownedCRDs {
+			data, ok := owned.(map[string]interface{})
+			if !ok || data["name"] != c.resource.GetName() {
+				continue
+			}
+			if c.serviceGVR != nil && data["version"] != c.serviceGVR.Version {
+				continue
+			}
+			descriptor := &olmv1alpha1.CRDDescription{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(data, descriptor); err != nil {
+				return nil, err
+			}
+			c.descriptor = (*pipeline.CRDDescription)(descriptor)
+			c.descriptorLookup = true
+			return c.descriptor, nil
+		}
+	}
+	c.descriptorLookup = true
+	return nil, nil
 }
 
 func (c *customResourceDefinition) Resource() *unstructured.Unstructured {
@@ -269,10 +379,9 @@ func (c *customResourceDefinition) IsBindable() (bool, error) {
 		return true, nil
 	}
 
-	annotations := make(map[string]string)
-	util.MergeMaps(annotations, c.resource.GetAnnotations())
-	if len(annotations) == 0 {
-		return false, nil
+	annotations, err := binding.DefinitionSources.Definitions(&crdDefinitionService{crd: c})
+	if err != nil {
+		return false, err
 	}
 	for k := range annotations {
 		if ok, err := binding.IsServiceBindingAnnotation(k); ok && err == nil {
@@ -281,3 +390,35 @@ func (c *customResourceDefinition) IsBindable() (bool, error) {
 	}
 	return false, nil
 }
+
+// crdDefinitionService exposes a CRD to binding definition sources as a service of the CRD kind without annotations,
+// so that definitions declared for the kind rather than for particular resources are found
+type crdDefinitionService struct {
+	crd *customResourceDefinition
+}
+
+var _ binding.Service = &crdDefinitionService{}
+
+func (s *crdDefinitionService) Resource() *unstructured.Unstructured {
+	gvk := schema.GroupVersionKind{Kind: s.crd.kind()}
+	if s.crd.serviceGVR != nil {
+		gvk = s.crd.serviceGVR.GroupVersion().WithKind(gvk.Kind)
+	} else {
+		gvk.Group, _, _ = unstructured.NestedString(s.crd.resource.Object, "spec", "group")
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	return u
+}
+
+func (s *crdDefinitionService) CustomResourceDefinition() (*unstructured.Unstructured, error) {
+	return s.crd.resource, nil
+}
+
+func (s *crdDefinitionService) Descriptor() (*olmv1alpha1.CRDDescription, error) {
+	descriptor, err := s.crd.Descriptor()
+	if err != nil || descriptor == nil {
+		return nil, err
+	}
+	return (*olmv1alpha1.CRDDescription)(descriptor), nil
+}
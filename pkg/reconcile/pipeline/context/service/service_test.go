@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"errors"
 	corev1 "k8s.io/api/core/v1"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/redhat-developer/service-binding-operator/pkg/binding"
 	"github.com/redhat-developer/service-binding-operator/pkg/binding/registry"
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes/mocks"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -19,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/testing"
 )
@@ -126,10 +129,14 @@ var _ = Describe("Service", func() {
 			s := runtime.NewScheme()
 			Expect(corev1.AddToScheme(s)).NotTo(HaveOccurred())
 			s.AddKnownTypeWithName(schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "RouteList"}, &unstructured.UnstructuredList{})
+
+			s.AddKnownTypeWithName(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSetList"}, &unstructured.UnstructuredList{})
+			s.AddKnownTypeWithName(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DeploymentList"}, &unstructured.UnstructuredList{})
 			client = fake.NewSimpleDynamicClient(s)
 
-			for i := range bindableResourceGVRs {
-				gvr := bindableResourceGVRs[i]
+			bindable := 0
+			for _, kind := range binding.OwnedKinds.Kinds() {
+				gvr := kind.GroupVersionResource
 
 				if gvr.Resource == "configmaps" {
 					client.PrependReactor("list", gvr.Resource, func(action testing.Action) (handled bool, ret runtime.Object, err error) {
@@ -138,15 +145,14 @@ var _ = Describe("Service", func() {
 					continue
 				}
 				ul := &unstructured.UnstructuredList{}
-				// compute kind
-				kind := strings.Title(gvr.Resource)[:len(gvr.Resource)-1] //nolint
-
-				gvk := gvr.GroupVersion().WithKind(kind)
-				ou := resource(gvk, "child1", ns, id)
+				ou := resource(kind.GroupVersionKind(), "child1", ns, id)
 
-				ou2 := resource(gvk, "child2", ns, id2)
+				ou2 := resource(kind.GroupVersionKind(), "child2", ns, id2)
 
-				children = append(children, ou)
+				if kind.Bindable() {
+					bindable++
+					children = append(children, ou)
+				}
 				ul.Items = append(ul.Items, *ou, *ou2)
 
 				client.PrependReactor("list", gvr.Resource, func(action testing.Action) (handled bool, ret runtime.Object, err error) {
@@ -158,10 +164,145 @@ var _ = Describe("Service", func() {
 
 			ownedResources, err := impl.OwnedResources()
 			Expect(err).NotTo(HaveOccurred())
-			Expect(ownedResources).Should(HaveLen(len(bindableResourceGVRs) - 1))
+			Expect(ownedResources).Should(HaveLen(bindable))
 			Expect(ownedResources).Should(ConsistOf(children...))
 		})
 
+		It("should return only resources owned directly by default", func() {
+			ns := "ns1"
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(schema.GroupVersionKind{Group: "foo.bar", Version: "v1", Kind: "Foo"})
+			u.SetName("foo")
+			u.SetNamespace(ns)
+			u.SetUID(uuid.NewUUID())
+
+			secret := resource(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, "secret", ns, u.GetUID())
+			svc := resource(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, "svc", ns, secret.GetUID())
+			client = fake.NewSimpleDynamicClient(scheme())
+			index := &ownerIndex{Interface: client, owned: map[types.UID][]*unstructured.Unstructured{
+				u.GetUID():      {secret},
+				secret.GetUID(): {svc},
+			}}
+
+			impl := &service{client: index, resource: u, lookForOwnedResources: true, namespace: ns}
+
+			ownedResources, err := impl.OwnedResources()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ownedResources).To(ConsistOf(secret))
+		})
+
+		It("should return resources owned by owned resources", func() {
+			depth := MaxOwnershipDepth
+			MaxOwnershipDepth = 3
+			for _, k := range binding.TraversedOwnedKinds {
+				binding.OwnedKinds.Register(k)
+			}
+			defer func() {
+				MaxOwnershipDepth = depth
+				for _, k := range binding.TraversedOwnedKinds {
+					binding.OwnedKinds.Remove(k.GroupVersionResource)
+				}
+			}()
+			ns := "ns1"
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(schema.GroupVersionKind{Group: "foo.bar", Version: "v1", Kind: "Foo"})
+			u.SetName("foo")
+			u.SetNamespace(ns)
+			u.SetUID(uuid.NewUUID())
+
+			statefulSet := resource(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}, "sts", ns, u.GetUID())
+			svc := resource(schema.GroupVersionKind{Version: "v1", Kind: "Service"}, "svc", ns, statefulSet.GetUID())
+			// owner references cycling back to the service are not followed again
+			secret := resource(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, "secret", ns, svc.GetUID())
+			secret.SetUID(u.GetUID())
+
+			owned := map[string]*unstructured.UnstructuredList{
+				"statefulsets": {Items: []unstructured.Unstructured{*statefulSet}},
+				"services":     {Items: []unstructured.Unstructured{*svc}},
+				"secrets":      {Items: []unstructured.Unstructured{*secret}},
+			}
+			var lists []runtime.Object
+			for _, kind := range binding.OwnedKinds.Kinds() {
+				if kind.Group != "" {
+					lists = append(lists, resource(kind.GroupVersionKind(), "", ns, ""))
+				}
+			}
+			client = fake.NewSimpleDynamicClient(scheme(lists...))
+			listed := make(map[string]int)
+			for _, kind := range binding.OwnedKinds.Kinds() {
+				resourceName := kind.Resource
+				client.PrependReactor("list", resourceName, func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+					listed[resourceName]++
+					if l, ok := owned[resourceName]; ok {
+						return true, l, nil
+					}
+					return true, &unstructured.UnstructuredList{}, nil
+				})
+			}
+
+			impl := &service{client: client, resource: u, lookForOwnedResources: true, namespace: ns}
+
+			ownedResources, err := impl.OwnedResources()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ownedResources).To(ConsistOf(svc))
+			for _, kind := range binding.OwnedKinds.Kinds() {
+				Expect(listed[kind.Resource]).To(Equal(1), kind.Resource)
+			}
+		})
+
+		It("should look up owned resources in the owner index of the client", func() {
+			ns := "ns1"
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(schema.GroupVersionKind{Group: "foo.bar", Version: "v1", Kind: "Foo"})
+			u.SetName("foo")
+			u.SetNamespace(ns)
+			u.SetUID(uuid.NewUUID())
+
+			secret := resource(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, "secret", ns, u.GetUID())
+			client = fake.NewSimpleDynamicClient(scheme())
+			index := &ownerIndex{Interface: client, owned: map[types.UID][]*unstructured.Unstructured{u.GetUID(): {secret}}}
+
+			impl := &service{client: index, resource: u, lookForOwnedResources: true, namespace: ns}
+
+			ownedResources, err := impl.OwnedResources()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ownedResources).To(ConsistOf(secret))
+			Expect(client.Actions()).To(BeEmpty())
+		})
+
+		It("should skip owned resources that are not allowed to be listed", func() {
+			ns := "ns1"
+			u := &unstructured.Unstructured{}
+			u.SetGroupVersionKind(schema.GroupVersionKind{Group: "foo.bar", Version: "v1", Kind: "Foo"})
+			u.SetName("foo")
+			u.SetNamespace(ns)
+			u.SetUID(uuid.NewUUID())
+
+			secret := resource(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, "secret", ns, u.GetUID())
+			var lists []runtime.Object
+			for _, kind := range binding.OwnedKinds.Kinds() {
+				if kind.Group != "" {
+					lists = append(lists, resource(kind.GroupVersionKind(), "", ns, ""))
+				}
+			}
+			client = fake.NewSimpleDynamicClient(scheme(lists...))
+			for _, kind := range binding.OwnedKinds.Kinds() {
+				gvr := kind.GroupVersionResource
+				client.PrependReactor("list", gvr.Resource, func(action testing.Action) (handled bool, ret runtime.Object, err error) {
+					if gvr.Resource == "secrets" {
+						return true, &unstructured.UnstructuredList{Items: []unstructured.Unstructured{*secret}}, nil
+					}
+					return true, nil, k8serrors.NewForbidden(gvr.GroupResource(), "", errors.New("denied"))
+				})
+			}
+
+			impl := &service{client: client, resource: u, lookForOwnedResources: true, namespace: ns}
+
+			ownedResources, err := impl.OwnedResources()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ownedResources).To(ConsistOf(secret))
+		})
+
 		DescribeTable("return error if occurs at looking at owned resources", func(failingResourceName string) {
 			id := uuid.NewUUID()
 			id2 := uuid.NewUUID()
@@ -175,18 +316,12 @@ var _ = Describe("Service", func() {
 			client = fake.NewSimpleDynamicClient(scheme())
 			expectedErr := errors.New("foo")
 
-			for _, gvr := range bindableResourceGVRs {
+			for _, kind := range binding.OwnedKinds.Kinds() {
+				gvr := kind.GroupVersionResource
 				ul := &unstructured.UnstructuredList{}
-				// compute kind
-				kind := strings.Title(gvr.Resource)[:len(gvr.Resource)-1] //nolint
-				// fix for ConfigMap
-				if kind == "Configmap" {
-					kind = "ConfigMap"
-				}
-				gvk := gvr.GroupVersion().WithKind(kind)
-				ou := resource(gvk, "child1", ns, id)
+				ou := resource(kind.GroupVersionKind(), "child1", ns, id)
 
-				ou2 := resource(gvk, "child2", ns, id2)
+				ou2 := resource(kind.GroupVersionKind(), "child2", ns, id2)
 
 				ul.Items = append(ul.Items, *ou, *ou2)
 
@@ -217,6 +352,7 @@ func resource(gvk schema.GroupVersionKind, name string, namespace string, owner
 	u.SetGroupVersionKind(gvk)
 	u.SetName(name)
 	u.SetNamespace(namespace)
+	u.SetUID(uuid.NewUUID())
 	u.SetOwnerReferences([]v1.OwnerReference{
 		{
 			UID: owner,
@@ -304,3 +440,18 @@ var _ = Describe("Builder", func() {
 		Expect(crd.Resource()).To(Equal(crdResource))
 	})
 })
+
+type ownerIndex struct {
+	dynamic.Interface
+	owned map[types.UID][]*unstructured.Unstructured
+}
+
+func (i *ownerIndex) OwnedBy(ctx context.Context, gvr schema.GroupVersionResource, namespace string, owner types.UID) ([]*unstructured.Unstructured, bool, error) {
+	var result []*unstructured.Unstructured
+	for _, item := range i.owned[owner] {
+		if item.GroupVersionKind().GroupVersion() == gvr.GroupVersion() && strings.EqualFold(item.GetKind()+"s", gvr.Resource) {
+			result = append(result, item)
+		}
+	}
+	return result, true, nil
+}
@@ -1,9 +1,7 @@
 package context
 
 import (
-	"context"
 	"fmt"
-	"time"
 
 	"github.com/redhat-developer/service-binding-operator/apis"
 	"github.com/redhat-developer/service-binding-operator/apis/spec/v1beta1"
@@ -11,82 +9,95 @@ import (
 	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/context/service"
-	"golang.org/x/time/rate"
 	v1 "k8s.io/api/authentication/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/dynamic"
 	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
-	"k8s.io/client-go/util/workqueue"
 )
 
 var _ pipeline.Context = &specImpl{}
 
-var SpecProvider = func(client dynamic.Interface, subjectAccessReviewClient authv1.SubjectAccessReviewInterface, typeLookup kubernetes.K8STypeLookup) pipeline.ContextProvider {
-	return &provider{
-		client:     client,
-		typeLookup: typeLookup,
-		get: func(binding interface{}) (pipeline.Context, error) {
-			switch sb := binding.(type) {
-			case *v1beta1.ServiceBinding:
-				if sb.Generation != 0 {
-					sb.Status.ObservedGeneration = sb.Generation
-				}
-				ctx := &specImpl{
-					impl: impl{
-						conditions:                make(map[string]*metav1.Condition),
-						client:                    client,
-						subjectAccessReviewClient: subjectAccessReviewClient,
-						typeLookup:                typeLookup,
-						bindingMeta:               &sb.ObjectMeta,
-						statusSecretName: func() string {
-							if sb.Status.Binding == nil {
-								return ""
-							}
-							return sb.Status.Binding.Name
-						},
-						setStatusSecretName: func(name string) {
-							sb.Status.Binding = &v1beta1.ServiceBindingSecretReference{Name: name}
-						},
-						unstructuredBinding: func() (*unstructured.Unstructured, error) {
-							return converter.ToUnstructured(sb)
-						},
-						statusConditions: func() *[]metav1.Condition {
-							return &sb.Status.Conditions
-						},
-						ownerReference: func() metav1.OwnerReference {
-							return sb.AsOwnerReference()
-						},
-						groupVersionResource: func() schema.GroupVersionResource {
-							return v1beta1.GroupVersionResource
-						},
-						requester: func() *v1.UserInfo {
-							return apis.Requester(sb.ObjectMeta)
-						},
-						serviceBuilder: service.NewBuilder(typeLookup).WithClient(client),
-						labelSelectionRateLimiter: workqueue.NewMaxOfRateLimiter(
-							workqueue.NewItemExponentialFailureRateLimiter(5*time.Millisecond, 2*time.Minute),
-							&workqueue.BucketRateLimiter{
-								Limiter: rate.NewLimiter(rate.Limit(10), 100),
-							},
-						),
+var SpecProvider = func(client dynamic.Interface, subjectAccessReviewClient authv1.SubjectAccessReviewInterface, typeLookup kubernetes.K8STypeLookup, opts ...ProviderOption) pipeline.ContextProvider {
+	p := newProvider(client, typeLookup, opts)
+	p.get = func(binding interface{}) (pipeline.Context, error) {
+		switch sb := binding.(type) {
+		case *v1beta1.ServiceBinding:
+			persisted, err := converter.ToUnstructured(sb)
+			if err != nil {
+				return nil, err
+			}
+			if sb.Generation != 0 {
+				sb.Status.ObservedGeneration = sb.Generation
+			}
+			// references are recorded again while collecting binding data
+			sb.Status.CrossNamespaceReferences = nil
+			sb.Status.DryRun = nil
+			reader := p.reader()
+			ctx := &specImpl{
+				impl: impl{
+					conditions:                make(map[string]*metav1.Condition),
+					client:                    client,
+					reader:                    reader,
+					subjectAccessReviewClient: subjectAccessReviewClient,
+					typeLookup:                typeLookup,
+					tracker:                   p.tracker,
+					crossNamespaceAllowList:   p.crossNamespaceAllowList,
+					bindingMeta:               &sb.ObjectMeta,
+					statusSecretName: func() string {
+						if sb.Status.Binding == nil {
+							return ""
+						}
+						return sb.Status.Binding.Name
 					},
-					serviceBinding: sb,
-				}
-				if sb.Spec.Type != "" {
-					ctx.AddBindingItem(&pipeline.BindingItem{Name: "type", Value: sb.Spec.Type})
-				}
-				if sb.Spec.Provider != "" {
-					ctx.AddBindingItem(&pipeline.BindingItem{Name: "provider", Value: sb.Spec.Provider})
-				}
-				return ctx, nil
+					setStatusSecretName: func(name string) {
+						sb.Status.Binding = &v1beta1.ServiceBindingSecretReference{Name: name}
+					},
+					setCrossNamespaceReferences: func(refs []apis.CrossNamespaceReference) {
+						sb.Status.CrossNamespaceReferences = refs
+					},
+					dryRun:        sb.Spec.DryRun || apis.DryRunRequested(sb.ObjectMeta),
+					failurePolicy: sb.Spec.FailurePolicy,
+					eventRecorder: &eventRecorder{recorder: p.recorder, binding: sb, bindingName: sb.Name},
+					setDryRunResult: func(result *apis.DryRunResult) {
+						sb.Status.DryRun = result
+					},
+					unstructuredBinding: func() (*unstructured.Unstructured, error) {
+						return converter.ToUnstructured(sb)
+					},
+					statusConditions: func() *[]metav1.Condition {
+						return &sb.Status.Conditions
+					},
+					workloadStatuses: func() *[]apis.WorkloadStatus {
+						return &sb.Status.Workloads
+					},
+					ownerReference: func() metav1.OwnerReference {
+						return sb.AsOwnerReference()
+					},
+					groupVersionResource: func() schema.GroupVersionResource {
+						return v1beta1.GroupVersionResource
+					},
+					requester: func() *v1.UserInfo {
+						return apis.Requester(sb.ObjectMeta)
+					},
+					serviceBuilder:   service.NewBuilder(typeLookup).WithClient(reader),
+					persistedBinding: persisted,
+				},
+				serviceBinding: sb,
 			}
-			return nil, fmt.Errorf("cannot create context for passed instance %v", binding)
-		},
+			if sb.Spec.Type != "" {
+				ctx.AddBindingItem(&pipeline.BindingItem{Name: "type", Value: sb.Spec.Type})
+			}
+			if sb.Spec.Provider != "" {
+				ctx.AddBindingItem(&pipeline.BindingItem{Name: "provider", Value: sb.Spec.Provider})
+			}
+			return ctx, nil
+		}
+		return nil, fmt.Errorf("cannot create context for passed instance %v", binding)
 	}
+	return p
 }
 
 type specImpl struct {
@@ -130,14 +141,15 @@ func (i *specImpl) Services() ([]pipeline.Service, error) {
 		if err != nil {
 			return nil, err
 		}
+		i.trackResource(*gvr, i.serviceBinding.Namespace, serviceRef.Name)
 		if !i.canPerform(gvr, serviceRef.Name, i.serviceBinding.Namespace, "get") {
 			return nil, fmt.Errorf("cannot read service %s in namespace %s", serviceRef.Name, i.serviceBinding.Namespace)
 		}
-		u, err := i.client.Resource(*gvr).Namespace(i.serviceBinding.Namespace).Get(context.Background(), serviceRef.Name, metav1.GetOptions{})
+		u, err := i.reader.Resource(*gvr).Namespace(i.serviceBinding.Namespace).Get(i.requestContext(), serviceRef.Name, metav1.GetOptions{})
 		if err != nil {
 			return nil, err
 		}
-		s, err := i.serviceBuilder.Build(u)
+		s, err := i.serviceBuilder.Build(u, service.RequestContextOption(i.requestContext))
 		if err != nil {
 			return nil, err
 		}
@@ -169,7 +181,9 @@ func (i *specImpl) Applications() ([]pipeline.Application, error) {
 			if !i.canPerform(gvr, ref.Name, i.serviceBinding.Namespace, "update") {
 				return nil, fmt.Errorf("cannot update application resource %s in namespace %s", ref.Name, i.serviceBinding.Namespace)
 			}
-			u, err := i.client.Resource(*gvr).Namespace(i.serviceBinding.Namespace).Get(context.Background(), ref.Name, metav1.GetOptions{})
+			// workloads are read from the API server, as they are applied with their resource version as precondition
+			// and cached copies might lag behind
+			u, err := i.client.Resource(*gvr).Namespace(i.serviceBinding.Namespace).Get(i.requestContext(), ref.Name, metav1.GetOptions{})
 			if err != nil {
 				return nil, emptyApplicationsErr{err}
 			}
@@ -182,15 +196,19 @@ func (i *specImpl) Applications() ([]pipeline.Application, error) {
 			})
 		}
 		if i.HasLabelSelector() {
-			matchLabels := i.serviceBinding.Spec.Workload.Selector.MatchLabels
+			selector, err := metav1.LabelSelectorAsSelector(i.serviceBinding.Spec.Workload.Selector)
+			if err != nil {
+				return nil, err
+			}
+			i.trackSelectedResources(*gvr, i.serviceBinding.Namespace, selector)
 			opts := metav1.ListOptions{
-				LabelSelector: labels.Set(matchLabels).String(),
+				LabelSelector: selector.String(),
 			}
 			if !i.canPerform(gvr, "", i.serviceBinding.Namespace, "list") {
 				return nil, fmt.Errorf("cannot read application in namespace %s", i.serviceBinding.Namespace)
 			}
 
-			objList, err := i.client.Resource(*gvr).Namespace(i.serviceBinding.Namespace).List(context.Background(), opts)
+			objList, err := i.client.Resource(*gvr).Namespace(i.serviceBinding.Namespace).List(i.requestContext(), opts)
 			if err != nil {
 				return nil, err
 			}
@@ -219,6 +237,13 @@ func (s *specImpl) BindAsFiles() bool {
 	return true
 }
 
+func (s *specImpl) RestartPolicy() apis.RestartPolicy {
+	if s.serviceBinding.Spec.RestartPolicy == "" {
+		return apis.RestartPolicyNever
+	}
+	return s.serviceBinding.Spec.RestartPolicy
+}
+
 func (s *specImpl) NamingTemplate() string {
 	return ""
 }
@@ -13,12 +13,14 @@ import (
 	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/apis"
 	specapi "github.com/redhat-developer/service-binding-operator/apis/spec/v1beta1"
+	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes/fakeapply"
 	"github.com/redhat-developer/service-binding-operator/pkg/client/kubernetes/mocks"
 	"github.com/redhat-developer/service-binding-operator/pkg/converter"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
 	pipelinemocks "github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/mocks"
 	corev1 "k8s.io/api/core/v1"
 	v1apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -133,6 +135,54 @@ var _ = Describe("Spec API Context", func() {
 			Expect(applications[0].Resource()).Should(BeElementOf(u1, u2))
 			Expect(applications[1].Resource()).Should(BeElementOf(u1, u2))
 		})
+		It("should return applications matching label selector expressions", func() {
+			ls := &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "env", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"dev"}},
+				},
+			}
+
+			ref := specapi.ServiceBindingWorkloadReference{
+				APIVersion: "app/v1",
+				Kind:       "Foo",
+				Selector:   ls,
+			}
+
+			sb := specapi.ServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "sb1",
+					Namespace: "ns1",
+				},
+				Spec: specapi.ServiceBindingSpec{
+					Workload: ref,
+				},
+			}
+			gvr := &schema.GroupVersionResource{Group: "app", Version: "v1", Resource: "foos"}
+			typeLookup.EXPECT().ResourceForReferable(&ref).Return(gvr, nil)
+
+			u1 := &unstructured.Unstructured{}
+			u1.SetName("app1")
+			u1.SetNamespace(sb.Namespace)
+			u1.SetGroupVersionKind(schema.GroupVersionKind{Group: "app", Version: "v1", Kind: "Foo"})
+			u1.SetLabels(map[string]string{"env": "prod"})
+
+			u2 := &unstructured.Unstructured{}
+			u2.SetName("app2")
+			u2.SetNamespace(sb.Namespace)
+			u2.SetGroupVersionKind(schema.GroupVersionKind{Group: "app", Version: "v1", Kind: "Foo"})
+			u2.SetLabels(map[string]string{"env": "dev"})
+
+			client := fake.NewSimpleDynamicClient(runtime.NewScheme(), u1, u2)
+			authClient := &fakeauth.FakeAuthorizationV1{}
+
+			ctx, err := SpecProvider(client, authClient.SubjectAccessReviews(), typeLookup).Get(&sb)
+			Expect(err).NotTo(HaveOccurred())
+
+			applications, err := ctx.Applications()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(applications).To(HaveLen(1))
+			Expect(applications[0].Resource()).To(Equal(u1))
+		})
 		It("should return error if no application is matching through label selector", func() {
 			ls := &metav1.LabelSelector{
 				MatchLabels: map[string]string{"env": "prod"},
@@ -695,7 +745,9 @@ var _ = Describe("Spec API Context", func() {
 			s := runtime.NewScheme()
 			Expect(specapi.AddToScheme(s)).NotTo(HaveOccurred())
 			Expect(corev1.AddToScheme(s)).NotTo(HaveOccurred())
-			client = fake.NewSimpleDynamicClient(s, u)
+			fakeClient := fake.NewSimpleDynamicClient(s, u)
+			fakeapply.AddReactor(fakeClient, FieldManager)
+			client = fakeClient
 
 			authClient := &fakeauth.FakeAuthorizationV1{}
 
@@ -870,10 +922,23 @@ var _ = Describe("Spec API Context", func() {
 			intermediateSecret := &corev1.Secret{}
 			err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, intermediateSecret)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(intermediateSecret.StringData).To(HaveLen(3))
-			Expect(intermediateSecret.StringData).Should(HaveKeyWithValue("foo1", "val1"))
-			Expect(intermediateSecret.StringData).Should(HaveKeyWithValue("foo2", "val2"))
-			Expect(intermediateSecret.StringData).Should(HaveKeyWithValue("foo3", "val3"))
+			Expect(intermediateSecret.Data).To(HaveLen(3))
+			Expect(intermediateSecret.Data).Should(HaveKeyWithValue("foo1", []byte("val1")))
+			Expect(intermediateSecret.Data).Should(HaveKeyWithValue("foo2", []byte("val2")))
+			Expect(intermediateSecret.Data).Should(HaveKeyWithValue("foo3", []byte("val3")))
+		})
+		It("should not create secret in dry-run mode", func() {
+			sb.Spec.DryRun = true
+			authClient := &fakeauth.FakeAuthorizationV1{}
+			ctx, _ = Provider(client, authClient.SubjectAccessReviews(), typeLookup).Get(sb)
+			ctx.AddBindingItem(&pipeline.BindingItem{Name: "foo", Value: "v1"})
+
+			err := ctx.PersistSecret()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sb.Status.Binding).To(BeNil())
+
+			_, err = ctx.ReadSecret(sb.Namespace, ctx.BindingSecretName())
+			Expect(errors.IsNotFound(err)).To(BeTrue())
 		})
 		It("should not update secret if the service binding's uid is unset", func() {
 			sb.UID = ""
@@ -900,7 +965,10 @@ var _ = Describe("Spec API Context", func() {
 			err = runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, secret)
 			Expect(err).NotTo(HaveOccurred())
 			bindingItems := ctx.BindingItems()
-			Expect(secret.StringData).To(Equal(bindingItems.AsMap()))
+			Expect(secret.Data).To(HaveLen(len(bindingItems)))
+			for k, v := range bindingItems.AsMap() {
+				Expect(secret.Data).To(HaveKeyWithValue(k, []byte(v)))
+			}
 			Expect(secret.OwnerReferences).To(HaveLen(0))
 		})
 	})
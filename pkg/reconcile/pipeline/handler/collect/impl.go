@@ -1,19 +1,19 @@
 package collect
 
 import (
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	"github.com/redhat-developer/service-binding-operator/apis"
 	"github.com/redhat-developer/service-binding-operator/pkg/binding"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
-	"github.com/redhat-developer/service-binding-operator/pkg/util"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 var DataNotMap = errors.New("Returned data are not a map, skip collecting")
@@ -42,23 +42,23 @@ func BindingDefinitions(ctx pipeline.Context) {
 	services, _ := ctx.Services()
 
 	for _, service := range services {
-		anns := make(map[string]string)
-		crd, err := service.CustomResourceDefinition()
+		anns, err := binding.DefinitionSources.Definitions(&definitionService{service: service})
 		if err != nil {
-			requestRetry(ctx, ErrorReadingCRD, err)
+			reason := ErrorReadingBindingReason
+			var serr *definitionSourceError
+			if errors.As(err, &serr) {
+				reason, err = serr.reason, serr.err
+			}
+			requestRetry(ctx, reason, err)
 			return
 		}
-		if crd != nil {
-			util.MergeMaps(anns, crd.Resource().GetAnnotations())
-		}
-
-		util.MergeMaps(anns, service.Resource().GetAnnotations())
 
 		for k, v := range anns {
-			definition, err := makeBindingDefinition(k, v, ctx)
+			definition, err := makeBindingDefinition(k, v, ctx, service)
 			if err != nil {
 				condition := notCollectionReadyCond(InvalidAnnotation, fmt.Errorf("Failed to create binding definition from \"%v: %v\": %v", k, v, err))
 				ctx.SetCondition(condition)
+				ctx.EventRecorder().Event(corev1.EventTypeWarning, InvalidAnnotation, condition.Message)
 				ctx.Error(err)
 				ctx.StopProcessing()
 			}
@@ -75,27 +75,36 @@ func BindingItems(ctx pipeline.Context) {
 	for _, service := range services {
 		serviceResource := service.Resource()
 		for _, bd := range service.BindingDefs() {
-			bindingValue, err := bd.Apply(serviceResource)
-			if err != nil {
-				requestRetry(ctx, ErrorReadingBindingReason, err)
-				return
-			}
-			if bd.NonExistingOptional(bindingValue) {
-				continue
-			}
-			val := bindingValue.Get()
-			v := reflect.ValueOf(val)
-			if v.Kind() != reflect.Map {
-				requestRetry(ctx, "DataNotMap", DataNotMap)
+			if !collectDefinition(ctx, service, bd, serviceResource) {
 				return
 			}
-			for _, n := range v.MapKeys() {
-				collectItems("", ctx, service, n, v.MapIndex(n).Interface())
-			}
 		}
 	}
 }
 
+// collectDefinition collects binding items defined by the given definition on the given resource,
+// returning false if processing has been stopped
+func collectDefinition(ctx pipeline.Context, service pipeline.Service, bd binding.Definition, u *unstructured.Unstructured) bool {
+	bindingValue, err := bd.Apply(u)
+	if err != nil {
+		requestRetry(ctx, ErrorReadingBindingReason, err)
+		return false
+	}
+	if bd.NonExistingOptional(bindingValue) {
+		return true
+	}
+	val := bindingValue.Get()
+	v := reflect.ValueOf(val)
+	if v.Kind() != reflect.Map {
+		requestRetry(ctx, "DataNotMap", DataNotMap)
+		return false
+	}
+	for _, n := range v.MapKeys() {
+		collectItems("", ctx, service, n, v.MapIndex(n).Interface())
+	}
+	return true
+}
+
 func ProvisionedService(ctx pipeline.Context) {
 	services, _ := ctx.Services()
 
@@ -158,52 +167,6 @@ func DirectSecretReference(ctx pipeline.Context) {
 	}
 }
 
-type pathMapping struct {
-	input     string
-	transform func(interface{}) (interface{}, error)
-	output    string
-}
-
-var bindableResources = map[schema.GroupVersionKind]pathMapping{
-	schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"}: {
-		input:  "data",
-		output: "",
-	},
-	schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"}: {
-		input: "data",
-		transform: func(i interface{}) (interface{}, error) {
-			v := reflect.ValueOf(i)
-			if v.Kind() != reflect.Map {
-				return nil, errors.New("data is not map")
-			}
-			result := map[string]string{}
-
-			for _, n := range v.MapKeys() {
-				b, err := base64.StdEncoding.DecodeString(fmt.Sprintf("%v", v.MapIndex(n).Interface()))
-				if err != nil {
-					return nil, err
-				}
-				key := fmt.Sprintf("%v", n.Interface())
-				result[key] = string(b)
-			}
-			return result, nil
-		},
-		output: "",
-	},
-	schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Service"}: {
-		input:  "spec.clusterIP",
-		output: "clusterIP",
-	},
-	schema.GroupVersionKind{
-		Group:   "route.openshift.io",
-		Version: "v1",
-		Kind:    "Route",
-	}: {
-		input:  "spec.host",
-		output: "host",
-	},
-}
-
 func OwnedResources(ctx pipeline.Context) {
 	services, err := ctx.Services()
 	if err != nil {
@@ -217,31 +180,53 @@ func OwnedResources(ctx pipeline.Context) {
 			return
 		}
 		for _, res := range ownedResources {
-			pathMapping, ok := bindableResources[res.GroupVersionKind()]
-			if !ok {
+			kind, ok := binding.OwnedKinds.Lookup(res.GroupVersionKind())
+			if !ok || !kind.Bindable() {
 				continue
 			}
-			val, found, err := unstructured.NestedFieldNoCopy(res.Object, strings.Split(pathMapping.input, ".")...)
-			if !found {
-				err = errors.New("Not found")
+			if len(kind.Definitions) > 0 {
+				if !collectOwnedDefinitions(ctx, service, kind, res) {
+					return
+				}
+				continue
 			}
+			val, err := kind.Value(res)
 			if err != nil {
 				requestRetry(ctx, ErrorReadingServicesReason, err)
 				return
 			}
-			if pathMapping.transform != nil {
-				val, err = pathMapping.transform(val)
-				if err != nil {
-					requestRetry(ctx, ErrorReadingServicesReason, err)
-					return
-				}
-			}
-			collectItems("", ctx, service, reflect.ValueOf(pathMapping.output), val)
+			collectItems("", ctx, service, reflect.ValueOf(kind.Name), val)
 		}
 	}
 }
 
+// collectOwnedDefinitions collects binding items defined by binding annotations of the given owned kind,
+// returning false if processing has been stopped
+func collectOwnedDefinitions(ctx pipeline.Context, service pipeline.Service, kind binding.OwnedKind, res *unstructured.Unstructured) bool {
+	keys := make([]string, 0, len(kind.Definitions))
+	for k := range kind.Definitions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		definition, err := makeBindingDefinition(k, kind.Definitions[k], ctx, service)
+		if err != nil {
+			requestRetry(ctx, InvalidAnnotation, err)
+			return false
+		}
+		if definition != nil && !collectDefinition(ctx, service, definition, res) {
+			return false
+		}
+	}
+	return true
+}
+
 func collectItems(prefix string, ctx pipeline.Context, service pipeline.Service, k reflect.Value, val interface{}) {
+	if b, ok := val.([]byte); ok {
+		// binary values are projected as they are
+		ctx.AddBindingItem(&pipeline.BindingItem{Name: prefix + k.String(), Value: b, Source: service})
+		return
+	}
 	v := reflect.ValueOf(val)
 	switch v.Kind() {
 	case reflect.Map:
@@ -278,13 +263,83 @@ func notCollectionReadyCond(reason string, err error) *metav1.Condition {
 	return apis.Conditions().NotCollectionReady().Reason(reason).Msg(err.Error()).Build()
 }
 
-func makeBindingDefinition(key string, value string, ctx pipeline.Context) (binding.Definition, error) {
+func makeBindingDefinition(key string, value string, ctx pipeline.Context, service pipeline.Service) (binding.Definition, error) {
 	return binding.NewDefinitionBuilder(key,
 		value,
 		func(namespace string, name string) (*unstructured.Unstructured, error) {
+			if namespace != service.Resource().GetNamespace() {
+				if err := ctx.AuthorizeCrossNamespaceReference(apis.CrossNamespaceReference{Kind: "ConfigMap", Namespace: namespace, Name: name}); err != nil {
+					return nil, err
+				}
+			}
 			return ctx.ReadConfigMap(namespace, name)
 		},
 		func(namespace string, name string) (*unstructured.Unstructured, error) {
+			if namespace != service.Resource().GetNamespace() {
+				if err := ctx.AuthorizeCrossNamespaceReference(apis.CrossNamespaceReference{Kind: "Secret", Namespace: namespace, Name: name}); err != nil {
+					return nil, err
+				}
+			}
 			return ctx.ReadSecret(namespace, name)
 		}).Build()
 }
+
+// definitionService exposes a pipeline service to binding definition sources
+type definitionService struct {
+	service pipeline.Service
+
+	crd       pipeline.CRD
+	crdLoaded bool
+}
+
+var _ binding.Service = &definitionService{}
+
+// definitionSourceError carries the condition reason of failures reading service metadata
+type definitionSourceError struct {
+	reason string
+	err    error
+}
+
+func (e *definitionSourceError) Error() string {
+	return e.err.Error()
+}
+
+func (e *definitionSourceError) Unwrap() error {
+	return e.err
+}
+
+func (s *definitionService) Resource() *unstructured.Unstructured {
+	return s.service.Resource()
+}
+
+func (s *definitionService) customResourceDefinition() (pipeline.CRD, error) {
+	if !s.crdLoaded {
+		crd, err := s.service.CustomResourceDefinition()
+		if err != nil {
+			return nil, &definitionSourceError{reason: ErrorReadingCRD, err: err}
+		}
+		s.crd = crd
+		s.crdLoaded = true
+	}
+	return s.crd, nil
+}
+
+func (s *definitionService) CustomResourceDefinition() (*unstructured.Unstructured, error) {
+	crd, err := s.customResourceDefinition()
+	if err != nil || crd == nil {
+		return nil, err
+	}
+	return crd.Resource(), nil
+}
+
+func (s *definitionService) Descriptor() (*olmv1alpha1.CRDDescription, error) {
+	crd, err := s.customResourceDefinition()
+	if err != nil || crd == nil {
+		return nil, err
+	}
+	descriptor, err := crd.Descriptor()
+	if err != nil {
+		return nil, &definitionSourceError{reason: ErrorReadingDescriptorReason, err: err}
+	}
+	return (*olmv1alpha1.CRDDescription)(descriptor), nil
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	olmv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 
 	"github.com/redhat-developer/service-binding-operator/apis"
 	"github.com/redhat-developer/service-binding-operator/pkg/binding"
 	bindingmocks "github.com/redhat-developer/service-binding-operator/pkg/binding/mocks"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -82,6 +85,7 @@ var _ = Describe("Collect Binding Definitions", func() {
 				crd := mocks.NewMockCRD(mockCtrl)
 				service1.EXPECT().CustomResourceDefinition().Return(crd, nil)
 				service1.EXPECT().Resource().Return(&unstructured.Unstructured{})
+				crd.EXPECT().Descriptor().Return(nil, nil)
 				crd.EXPECT().Resource().Return(&unstructured.Unstructured{})
 
 				service2 := mocks.NewMockService(mockCtrl)
@@ -91,6 +95,19 @@ var _ = Describe("Collect Binding Definitions", func() {
 
 			shouldRetry(pipeline.HandlerFunc(collect.BindingDefinitions), collect.ErrorReadingCRD, err)
 		})
+
+		Context("on error reading CRD descriptor", func() {
+
+			BeforeEach(func() {
+				service := mocks.NewMockService(mockCtrl)
+				crd := mocks.NewMockCRD(mockCtrl)
+				service.EXPECT().CustomResourceDefinition().Return(crd, nil)
+				crd.EXPECT().Descriptor().Return(nil, err)
+				ctx.EXPECT().Services().Return([]pipeline.Service{service}, nil)
+			})
+
+			shouldRetry(pipeline.HandlerFunc(collect.BindingDefinitions), collect.ErrorReadingDescriptorReason, err)
+		})
 	})
 	Describe("successful processing", func() {
 
@@ -117,12 +134,15 @@ var _ = Describe("Collect Binding Definitions", func() {
 				serviceContent *unstructured.Unstructured
 				crd            *mocks.MockCRD
 				crdContent     *unstructured.Unstructured
+				crdDescription *pipeline.CRDDescription
 			)
 			BeforeEach(func() {
 				service, serviceContent = defService()
 
 				crd = mocks.NewMockCRD(mockCtrl)
 				crdContent = &unstructured.Unstructured{}
+				crdDescription = nil
+				crd.EXPECT().Descriptor().DoAndReturn(func() (*pipeline.CRDDescription, error) { return crdDescription, nil })
 				crd.EXPECT().Resource().Return(crdContent)
 
 				service.EXPECT().CustomResourceDefinition().Return(crd, nil)
@@ -168,6 +188,55 @@ var _ = Describe("Collect Binding Definitions", func() {
 				collect.BindingDefinitions(ctx)
 			})
 
+			It("should extract binding definitions from CRD descriptors", func() {
+				crdDescription = &pipeline.CRDDescription{
+					StatusDescriptors: []olmv1alpha1.StatusDescriptor{
+						{
+							Path:         "foo",
+							XDescriptors: []string{"service.binding"},
+						},
+					},
+					SpecDescriptors: []olmv1alpha1.SpecDescriptor{
+						{
+							Path:         "foo2",
+							XDescriptors: []string{"service.binding:foo2"},
+						},
+					},
+				}
+				service.EXPECT().AddBindingDef(bindingDefPath([]string{"status", "foo"}))
+				service.EXPECT().AddBindingDef(bindingDefPath([]string{"spec", "foo2"}))
+				collect.BindingDefinitions(ctx)
+			})
+
+			It("binding definitions on CRD and service take precedence over those from CRD descriptors", func() {
+				crdDescription = &pipeline.CRDDescription{
+					SpecDescriptors: []olmv1alpha1.SpecDescriptor{
+						{
+							Path:         "foo",
+							XDescriptors: []string{"service.binding:foo"},
+						},
+						{
+							Path:         "foo2",
+							XDescriptors: []string{"service.binding:foo2"},
+						},
+						{
+							Path:         "foo3",
+							XDescriptors: []string{"service.binding:foo3"},
+						},
+					},
+				}
+				crdContent.SetAnnotations(map[string]string{
+					"service.binding/foo2": "path={.status.foo2}",
+				})
+				serviceContent.SetAnnotations(map[string]string{
+					"service.binding/foo3": "path={.status.foo3}",
+				})
+				service.EXPECT().AddBindingDef(bindingDefPath([]string{"spec", "foo"}))
+				service.EXPECT().AddBindingDef(bindingDefPath([]string{"status", "foo2"}))
+				service.EXPECT().AddBindingDef(bindingDefPath([]string{"status", "foo3"}))
+				collect.BindingDefinitions(ctx)
+			})
+
 			Context("non OLM environment", func() {
 				It("should extract binding definitions both from service and CRD annotations", func() {
 					crdContent.SetAnnotations(map[string]string{
@@ -307,6 +376,9 @@ var _ = Describe("Collect Binding Data", func() {
 					Msg("Failed to create binding definition from \"service.binding/foo: path={.status.foo},elementType=asdf\": Annotation service.binding/foo: path={.status.foo},elementType=asdf not implemented!").
 					Reason(collect.InvalidAnnotation).Build()
 				ctx.EXPECT().SetCondition(condition)
+				recorder := mocks.NewMockEventRecorder(mockCtrl)
+				ctx.EXPECT().EventRecorder().Return(recorder)
+				recorder.EXPECT().Event(corev1.EventTypeWarning, collect.InvalidAnnotation, condition.Message)
 				ctx.EXPECT().Error(errors.New("Annotation service.binding/foo: path={.status.foo},elementType=asdf not implemented!"))
 				ctx.EXPECT().StopProcessing()
 
@@ -322,6 +394,9 @@ var _ = Describe("Collect Binding Data", func() {
 					Msg("Failed to create binding definition from \"service.binding/foo: path={.status.foo},objectType=asdf\": Annotation service.binding/foo: path={.status.foo},objectType=asdf not implemented!").
 					Reason(collect.InvalidAnnotation).Build()
 				ctx.EXPECT().SetCondition(condition)
+				recorder := mocks.NewMockEventRecorder(mockCtrl)
+				ctx.EXPECT().EventRecorder().Return(recorder)
+				recorder.EXPECT().Event(corev1.EventTypeWarning, collect.InvalidAnnotation, condition.Message)
 				ctx.EXPECT().Error(errors.New("Annotation service.binding/foo: path={.status.foo},objectType=asdf not implemented!"))
 				ctx.EXPECT().StopProcessing()
 
@@ -558,6 +633,35 @@ var _ = Describe("Collect From Owned Resources", func() {
 				collect.OwnedResources(ctx)
 			})
 
+			It("should collect binary values from owned secrets and configmaps", func() {
+
+				service1, _ := defService()
+				binary := []byte{0xde, 0xad, 0xbe, 0xef}
+				secret := &unstructured.Unstructured{Object: map[string]interface{}{
+					"data": map[string]interface{}{
+						"keystore": base64.StdEncoding.EncodeToString(binary),
+					},
+				}}
+				secret.SetGroupVersionKind(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"})
+				configMap := &unstructured.Unstructured{Object: map[string]interface{}{
+					"data": map[string]interface{}{
+						"host": "example.com",
+					},
+					"binaryData": map[string]interface{}{
+						"truststore": base64.StdEncoding.EncodeToString([]byte("certs")),
+					},
+				}}
+				configMap.SetGroupVersionKind(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "ConfigMap"})
+
+				service1.EXPECT().OwnedResources().Return([]*unstructured.Unstructured{secret, configMap}, nil)
+
+				ctx.EXPECT().AddBindingItem(&pipeline.BindingItem{Name: "keystore", Value: binary, Source: service1})
+				ctx.EXPECT().AddBindingItem(&pipeline.BindingItem{Name: "host", Value: "example.com", Source: service1})
+				ctx.EXPECT().AddBindingItem(&pipeline.BindingItem{Name: "truststore", Value: []byte("certs"), Source: service1})
+
+				collect.OwnedResources(ctx)
+			})
+
 			It("should collect bindings from owned secrets", func() {
 
 				service1, _ := defService()
@@ -574,6 +678,47 @@ var _ = Describe("Collect From Owned Resources", func() {
 
 				collect.OwnedResources(ctx)
 			})
+
+			It("should collect bindings from owned resources with binding annotations of profiles", func() {
+				Expect(binding.OwnedKinds.SetProfile("ingress", binding.OwnedKind{
+					GroupVersionResource: schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+					Kind:                 "Ingress",
+					Definitions:          map[string]string{"service.binding/host": "path={.spec.rules[0].host}"},
+				})).To(Succeed())
+				defer binding.OwnedKinds.RemoveProfile("ingress")
+				Expect(binding.OwnedKinds.SetProfile("service-ports", binding.OwnedKind{
+					GroupVersionResource: schema.GroupVersionResource{Version: "v1", Resource: "services"},
+					Kind:                 "Service",
+					Definitions:          map[string]string{"service.binding/ports": "path={.spec.ports},elementType=sliceOfMaps,sourceKey=name,sourceValue=port"},
+				})).To(Succeed())
+				defer binding.OwnedKinds.RemoveProfile("service-ports")
+
+				service1, _ := defService()
+				ingress := &unstructured.Unstructured{Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"rules": []interface{}{
+							map[string]interface{}{"host": "example.com"},
+						},
+					},
+				}}
+				ingress.SetGroupVersionKind(schema.GroupVersionKind{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress"})
+				svr := &unstructured.Unstructured{Object: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"clusterIP": "val1",
+						"ports": []interface{}{
+							map[string]interface{}{"name": "http", "port": "8080"},
+						},
+					},
+				}}
+				svr.SetGroupVersionKind(schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Service"})
+
+				service1.EXPECT().OwnedResources().Return([]*unstructured.Unstructured{ingress, svr}, nil)
+
+				ctx.EXPECT().AddBindingItem(&pipeline.BindingItem{Name: "host", Value: "example.com", Source: service1})
+				ctx.EXPECT().AddBindingItem(&pipeline.BindingItem{Name: "ports_http", Value: "8080", Source: service1})
+
+				collect.OwnedResources(ctx)
+			})
 		})
 	})
 
@@ -973,6 +1118,58 @@ var _ = Describe("Integration Collect definitions + items", func() {
 		}),
 	)
 
+	Describe("cross-namespace references", func() {
+		var bindingDefs []binding.Definition
+
+		BeforeEach(func() {
+			bindingDefs = nil
+			serviceResource.SetUnstructuredContent(map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"namespace": "n1",
+					"annotations": map[string]interface{}{
+						"service.binding/password": "path={.status.secret},objectType=Secret,sourceKey=password,namespace=credentials",
+						"service.binding/host":     "path={.status.config},objectType=ConfigMap,sourceKey=host,namespacePath={.status.namespace}",
+					},
+				},
+				"status": map[string]interface{}{
+					"secret":    "db-secret",
+					"config":    "db-config",
+					"namespace": "n1",
+				},
+			})
+			service.EXPECT().AddBindingDef(gomock.Any()).DoAndReturn(func(bd binding.Definition) {
+				bindingDefs = append(bindingDefs, bd)
+			}).Times(2)
+			service.EXPECT().BindingDefs().DoAndReturn(func() []binding.Definition { return bindingDefs })
+			ctx.EXPECT().ReadConfigMap("n1", "db-config").Return(&unstructured.Unstructured{Object: map[string]interface{}{
+				"data": map[string]interface{}{"host": "example.com"},
+			}}, nil).MaxTimes(1)
+		})
+
+		It("should read secrets from other namespaces when authorized", func() {
+			ref := apis.CrossNamespaceReference{Kind: "Secret", Namespace: "credentials", Name: "db-secret"}
+			ctx.EXPECT().AuthorizeCrossNamespaceReference(ref).Return(nil)
+			ctx.EXPECT().ReadSecret("credentials", "db-secret").Return(&unstructured.Unstructured{Object: map[string]interface{}{
+				"data": map[string]interface{}{"password": base64.StdEncoding.EncodeToString([]byte("secret"))},
+			}}, nil)
+			ctx.EXPECT().AddBindingItem(&pipeline.BindingItem{Name: "password", Value: "secret", Source: service})
+			ctx.EXPECT().AddBindingItem(&pipeline.BindingItem{Name: "host", Value: "example.com", Source: service})
+
+			collect.BindingDefinitions(ctx)
+			collect.BindingItems(ctx)
+		})
+
+		It("should not read secrets from other namespaces when not authorized", func() {
+			err := errors.New("not allowed")
+			ctx.EXPECT().AuthorizeCrossNamespaceReference(gomock.Any()).Return(err)
+			ctx.EXPECT().AddBindingItem(gomock.Any()).AnyTimes()
+			ctx.EXPECT().RetryProcessing(err)
+			ctx.EXPECT().SetCondition(apis.Conditions().NotCollectionReady().Reason(collect.ErrorReadingBindingReason).Msg(err.Error()).Build())
+
+			collect.BindingDefinitions(ctx)
+			collect.BindingItems(ctx)
+		})
+	})
 })
 
 type bindingDefMatcher struct {
@@ -3,11 +3,15 @@ package mapping
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"text/template"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
+	corev1 "k8s.io/api/core/v1"
 )
 
+const MappingError = "MappingError"
+
 func Handle(ctx pipeline.Context) {
 	bindingItems := ctx.BindingItems()
 	templateVars := make(map[string]interface{})
@@ -25,15 +29,13 @@ func Handle(ctx pipeline.Context) {
 	for name, valueTemplate := range ctx.Mappings() {
 		tmpl, err := template.New("mappings").Funcs(template.FuncMap{"json": marshalToJSON}).Parse(valueTemplate)
 		if err != nil {
-			ctx.StopProcessing()
-			ctx.Error(err)
+			stop(ctx, name, err)
 			return
 		}
 		var buf bytes.Buffer
 		err = tmpl.Execute(&buf, templateVars)
 		if err != nil {
-			ctx.StopProcessing()
-			ctx.Error(err)
+			stop(ctx, name, err)
 			return
 		}
 		ctx.AddBindingItem(&pipeline.BindingItem{Name: name, Value: buf.String()})
@@ -41,6 +43,12 @@ func Handle(ctx pipeline.Context) {
 
 }
 
+func stop(ctx pipeline.Context, name string, err error) {
+	ctx.StopProcessing()
+	ctx.Error(err)
+	ctx.EventRecorder().Event(corev1.EventTypeWarning, MappingError, fmt.Sprintf("Failed to compute binding %s: %v", name, err))
+}
+
 func marshalToJSON(m interface{}) (string, error) {
 	bytes, err := json.Marshal(m)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/handler/mapping"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/mocks"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -77,6 +78,9 @@ var _ = Describe("Mapping Handler", func() {
 		ctx.EXPECT().Mappings().Return(mappings)
 		ctx.EXPECT().StopProcessing()
 		ctx.EXPECT().Error(gomock.Any())
+		recorder := mocks.NewMockEventRecorder(mockCtrl)
+		ctx.EXPECT().EventRecorder().Return(recorder)
+		recorder.EXPECT().Event(corev1.EventTypeWarning, mapping.MappingError, gomock.Any())
 
 		mapping.Handle(ctx)
 	},
@@ -4,6 +4,7 @@ import (
 	"github.com/redhat-developer/service-binding-operator/apis"
 	"github.com/redhat-developer/service-binding-operator/pkg/naming"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const StrategyError = "NamingStrategyError"
@@ -37,4 +38,5 @@ func stop(ctx pipeline.Context, err error) {
 	ctx.Error(err)
 	ctx.StopProcessing()
 	ctx.SetCondition(apis.Conditions().NotCollectionReady().Reason(StrategyError).Msg(err.Error()).Build())
+	ctx.EventRecorder().Event(corev1.EventTypeWarning, StrategyError, err.Error())
 }
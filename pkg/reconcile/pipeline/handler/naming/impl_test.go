@@ -9,6 +9,7 @@ import (
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/handler/naming"
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/mocks"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -109,6 +110,9 @@ var _ = Describe("Naming handler", func() {
 			ctx.EXPECT().SetCondition(gomock.Any()).Do(func(condition *v1.Condition) {
 				Expect(condition).To(Equal(apis.Conditions().NotCollectionReady().Reason(naming.StrategyError).Msg(err.Error()).Build()))
 			})
+			recorder := mocks.NewMockEventRecorder(mockCtrl)
+			ctx.EXPECT().EventRecorder().Return(recorder)
+			recorder.EXPECT().Event(corev1.EventTypeWarning, naming.StrategyError, gomock.Any())
 			naming.Handle(ctx)
 		},
 		Entry("not valid template", "{{ .name"),
@@ -11,6 +11,14 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+const (
+	// InjectionErrorReason is used in events about workloads binding data cannot be injected into
+	InjectionErrorReason = "InjectionError"
+
+	// UnboundReason is used in events about workloads binding data have been removed from
+	UnboundReason = apis.UnboundReason
+)
+
 func PreFlightCheck(mandatoryBindingKeys ...string) func(pipeline.Context) {
 	return func(ctx pipeline.Context) {
 		if ctx.PersistSecret() != nil {
@@ -53,10 +61,6 @@ func PreFlightCheck(mandatoryBindingKeys ...string) func(pipeline.Context) {
 
 func PostFlightCheck(ctx pipeline.Context) {
 	ctx.SetCondition(apis.Conditions().InjectionReady().Reason("ApplicationUpdated").Build())
-	if ctx.HasLabelSelector() {
-		// Force periodic reprocessing of this service binding to catch new workloads
-		ctx.DelayReprocessing(nil)
-	}
 }
 
 func InjectSecretRef(ctx pipeline.Context) {
@@ -70,8 +74,7 @@ func InjectSecretRef(ctx pipeline.Context) {
 		}
 
 		err := unstructured.SetNestedField(app.Resource().Object, ctx.BindingSecretName(), strings.Split(secretPath, ".")...)
-		if err != nil {
-			stop(ctx, err)
+		if err != nil && failApplication(ctx, app, err) {
 			return
 		}
 
@@ -113,28 +116,33 @@ func BindingsAsEnv(ctx pipeline.Context) {
 	}
 
 	for _, app := range applications {
-		containerResources, err := app.BindablePods()
-		if err != nil {
-			if app.SecretPath() != "" {
-				continue
-			}
-			stop(ctx, err)
+		if err := bindAsEnv(app, envVars, envFromSecret, ctx.BindAsFiles()); err != nil && failApplication(ctx, app, err) {
 			return
 		}
-		for _, container := range containerResources.Containers {
-			if !ctx.BindAsFiles() {
-				// Safety: EnvFrom is available when ctx.BindAsFiles() == false
-				if err := container.AddEnvFromVar(envFromSecret); err != nil {
-					stop(ctx, err)
-					return
-				}
-			}
-			if err := container.AddEnvVars(envVars); err != nil {
-				stop(ctx, err)
-				return
+	}
+}
+
+func bindAsEnv(app pipeline.Application, envVars []corev1.EnvVar, envFromSecret corev1.EnvFromSource, bindAsFiles bool) error {
+	containerResources, err := app.BindablePods()
+	if err != nil {
+		if app.SecretPath() != "" {
+			return nil
+		}
+		return err
+	}
+	for _, container := range containerResources.Containers {
+		if !bindAsFiles {
+			// Safety: EnvFrom is available when ctx.BindAsFiles() == false
+			if err := container.AddEnvFromVar(envFromSecret); err != nil {
+				return err
 			}
 		}
+		if err := container.AddEnvVars(envVars); err != nil {
+			return err
+		}
+		app.AddBoundContainer(container.Name, "")
 	}
+	return nil
 }
 
 func BindingsAsFiles(ctx pipeline.Context) {
@@ -145,43 +153,73 @@ func BindingsAsFiles(ctx pipeline.Context) {
 	bindingName := ctx.BindingName()
 	applications, _ := ctx.Applications()
 	for _, app := range applications {
-		containerResources, err := app.BindablePods()
-		if err != nil {
-			if app.SecretPath() != "" {
-				continue
-			}
-			stop(ctx, err)
+		if err := bindAsFiles(app, bindingName, secretName); err != nil && failApplication(ctx, app, err) {
 			return
 		}
+	}
+}
 
-		volume := corev1.Volume{
-			Name: bindingName,
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: secretName,
-				},
+func bindAsFiles(app pipeline.Application, bindingName string, secretName string) error {
+	containerResources, err := app.BindablePods()
+	if err != nil {
+		if app.SecretPath() != "" {
+			return nil
+		}
+		return err
+	}
+
+	volume := corev1.Volume{
+		Name: bindingName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
 			},
+		},
+	}
+	if err := containerResources.AddVolume(volume); err != nil {
+		return err
+	}
+
+	for _, container := range containerResources.Containers {
+		mountPath, err := container.MountPath(bindingName)
+		if err != nil {
+			return err
 		}
-		if err := containerResources.AddVolume(volume); err != nil {
-			stop(ctx, err)
-			return
+		volumeMount := corev1.VolumeMount{
+			Name:      bindingName,
+			MountPath: mountPath,
 		}
 
-		for _, container := range containerResources.Containers {
-			mountPath, err := container.MountPath(ctx.BindingName())
-			if err != nil {
-				stop(ctx, err)
-				return
-			}
-			volumeMount := corev1.VolumeMount{
-				Name:      bindingName,
-				MountPath: mountPath,
-			}
+		if err := container.AddVolumeMount(volumeMount); err != nil {
+			return err
+		}
+		app.AddBoundContainer(container.Name, mountPath)
+	}
+	return nil
+}
 
-			if err := container.AddVolumeMount(volumeMount); err != nil {
-				stop(ctx, err)
+// RestartOnChange stamps hash of binding data into pod template annotations of bound applications if
+// requested by the binding restart policy, so that application controllers roll out new pods on its change
+func RestartOnChange(ctx pipeline.Context) {
+	if ctx.RestartPolicy() != apis.RestartPolicyOnChange {
+		return
+	}
+	key := apis.BindingDataHashAnnotationKey(ctx.BindingName())
+	hash := ctx.BindingDataHash()
+	applications, _ := ctx.Applications()
+	for _, app := range applications {
+		containerResources, err := app.BindablePods()
+		if err != nil {
+			if app.SecretPath() != "" {
+				continue
+			}
+			if failApplication(ctx, app, err) {
 				return
 			}
+			continue
+		}
+		if err := containerResources.SetAnnotation(key, hash); err != nil && failApplication(ctx, app, err) {
+			return
 		}
 	}
 }
@@ -212,6 +250,7 @@ func Unbind(ctx pipeline.Context) {
 			stop(ctx, err)
 			return
 		}
+		containerResources.RemoveAnnotation(apis.BindingDataHashAnnotationKey(bindingName))
 		for _, container := range containerResources.Containers {
 			for _, env := range envVars {
 				if err := container.RemoveEnvVars(env.Name); err != nil {
@@ -242,3 +281,15 @@ func stop(ctx pipeline.Context, err error) {
 	ctx.Error(err)
 	ctx.SetCondition(apis.Conditions().NotInjectionReady().Reason("Error").Msg(err.Error()).Build())
 }
+
+// failApplication records the error preventing binding data to be injected into the application, and stops processing
+// unless the binding is processed on a best-effort basis; returns true if processing has been stopped
+func failApplication(ctx pipeline.Context, app pipeline.Application, err error) bool {
+	app.SetError(err)
+	ctx.EventRecorder().WorkloadEvent(app, corev1.EventTypeWarning, InjectionErrorReason, err.Error())
+	if ctx.FailurePolicy() == apis.FailurePolicyBestEffort {
+		return false
+	}
+	stop(ctx, err)
+	return true
+}
@@ -18,6 +18,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline/mocks"
 )
@@ -82,6 +83,7 @@ var _ = Describe("Inject Bindings as Env vars handler", func() {
 				var metaContainers []pipeline.MetaContainer
 				for _, c := range containers {
 					name, _, _ := unstructured.NestedString(c, "name")
+					app.EXPECT().AddBoundContainer(name, "")
 					metaContainers = append(metaContainers, pipeline.MetaContainer{
 						Data:        c,
 						Name:        name,
@@ -208,6 +210,7 @@ var _ = Describe("Inject Bindings as Env vars handler", func() {
 				var metaContainers []pipeline.MetaContainer
 				for _, c := range containers {
 					name, _, _ := unstructured.NestedString(c, "name")
+					app.EXPECT().AddBoundContainer(name, "")
 					metaContainers = append(metaContainers, pipeline.MetaContainer{
 						Data:        c,
 						Name:        name,
@@ -313,11 +316,14 @@ var _ = Describe("Injection Preflight checks", func() {
 	var (
 		mockCtrl *gomock.Controller
 		ctx      *mocks.MockContext
+		recorder *mocks.MockEventRecorder
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		ctx = mocks.NewMockContext(mockCtrl)
+		recorder = mocks.NewMockEventRecorder(mockCtrl)
+		ctx.EXPECT().EventRecorder().Return(recorder).AnyTimes()
 	})
 
 	AfterEach(func() {
@@ -386,16 +392,8 @@ var _ = Describe("Injection Postflight checks", func() {
 		mockCtrl.Finish()
 	})
 
-	It("should trigger rebinding when labels are present", func() {
+	It("should set injection ready condition without scheduling rebinding", func() {
 		ctx.EXPECT().SetCondition(apis.Conditions().InjectionReady().Reason("ApplicationUpdated").Build())
-		ctx.EXPECT().HasLabelSelector().Return(true)
-		ctx.EXPECT().DelayReprocessing(nil)
-		project.PostFlightCheck(ctx)
-	})
-
-	It("should not trigger rebinding when labels are not present", func() {
-		ctx.EXPECT().SetCondition(apis.Conditions().InjectionReady().Reason("ApplicationUpdated").Build())
-		ctx.EXPECT().HasLabelSelector().Return(false)
 		project.PostFlightCheck(ctx)
 	})
 })
@@ -404,11 +402,14 @@ var _ = Describe("Inject bindings as files", func() {
 	var (
 		mockCtrl *gomock.Controller
 		ctx      *mocks.MockContext
+		recorder *mocks.MockEventRecorder
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		ctx = mocks.NewMockContext(mockCtrl)
+		recorder = mocks.NewMockEventRecorder(mockCtrl)
+		ctx.EXPECT().EventRecorder().Return(recorder).AnyTimes()
 		ctx.EXPECT().BindAsFiles().Return(true)
 	})
 
@@ -553,6 +554,7 @@ var _ = Describe("Inject bindings as files", func() {
 				var metaContainers []pipeline.MetaContainer
 				for _, c := range containers {
 					name, _, _ := unstructured.NestedString(c, "name")
+					app.EXPECT().AddBoundContainer(name, gomock.Any())
 					metaContainers = append(metaContainers, pipeline.MetaContainer{
 						Data:        c,
 						Name:        name,
@@ -766,17 +768,167 @@ var _ = Describe("Inject bindings as files", func() {
 
 		})
 	})
+	It("should record the mount path and the error of each application", func() {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment("d1", []corev1.Container{{Name: "c1", Image: "foo"}}))
+		Expect(err).NotTo(HaveOccurred())
+		containers, _, _ := converter.NestedResources(&corev1.Container{}, u, strings.Split("spec.template.spec.containers", ".")...)
+		template := &pipeline.MetaPodSpec{
+			Containers: []pipeline.MetaContainer{{
+				Data:        containers[0],
+				Name:        "c1",
+				Env:         []string{"env"},
+				VolumeMount: []string{"volumeMounts"},
+			}},
+			Volume: strings.Split("spec.template.spec.volumes", "."),
+			Data:   u,
+		}
+		app1 := mocks.NewMockApplication(mockCtrl)
+		app1.EXPECT().BindablePods().Return(template, nil)
+		app1.EXPECT().AddBoundContainer("c1", "/bindings/sb1")
+		app2 := mocks.NewMockApplication(mockCtrl)
+		err = errors.New("no containers found")
+		app2.EXPECT().BindablePods().Return(nil, err)
+		app2.EXPECT().SecretPath().Return("")
+		app2.EXPECT().SetError(err)
+		recorder.EXPECT().WorkloadEvent(app2, corev1.EventTypeWarning, project.InjectionErrorReason, err.Error())
+		ctx.EXPECT().BindingSecretName().Return("secret1")
+		ctx.EXPECT().BindingName().Return("sb1").AnyTimes()
+		ctx.EXPECT().Applications().Return([]pipeline.Application{app1, app2}, nil)
+		ctx.EXPECT().FailurePolicy().Return(apis.FailurePolicyFailFast)
+		ctx.EXPECT().StopProcessing()
+		ctx.EXPECT().Error(err)
+		ctx.EXPECT().SetCondition(apis.Conditions().NotInjectionReady().Reason("Error").Msg(err.Error()).Build())
+
+		project.BindingsAsFiles(ctx)
+	})
+
+	It("should bind remaining applications when processed on a best-effort basis", func() {
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment("d1", []corev1.Container{{Name: "c1", Image: "foo"}}))
+		Expect(err).NotTo(HaveOccurred())
+		containers, _, _ := converter.NestedResources(&corev1.Container{}, u, strings.Split("spec.template.spec.containers", ".")...)
+		template := &pipeline.MetaPodSpec{
+			Containers: []pipeline.MetaContainer{{
+				Data:        containers[0],
+				Name:        "c1",
+				Env:         []string{"env"},
+				VolumeMount: []string{"volumeMounts"},
+			}},
+			Volume: strings.Split("spec.template.spec.volumes", "."),
+			Data:   u,
+		}
+		app1 := mocks.NewMockApplication(mockCtrl)
+		err = errors.New("no containers found")
+		app1.EXPECT().BindablePods().Return(nil, err)
+		app1.EXPECT().SecretPath().Return("")
+		app1.EXPECT().SetError(err)
+		recorder.EXPECT().WorkloadEvent(app1, corev1.EventTypeWarning, project.InjectionErrorReason, err.Error())
+		app2 := mocks.NewMockApplication(mockCtrl)
+		app2.EXPECT().BindablePods().Return(template, nil)
+		app2.EXPECT().AddBoundContainer("c1", "/bindings/sb1")
+		ctx.EXPECT().BindingSecretName().Return("secret1")
+		ctx.EXPECT().BindingName().Return("sb1").AnyTimes()
+		ctx.EXPECT().Applications().Return([]pipeline.Application{app1, app2}, nil)
+		ctx.EXPECT().FailurePolicy().Return(apis.FailurePolicyBestEffort)
+
+		project.BindingsAsFiles(ctx)
+
+		d := &appsv1.Deployment{}
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(u, d)).To(Succeed())
+		Expect(d.Spec.Template.Spec.Volumes).To(HaveLen(1))
+		Expect(d.Spec.Template.Spec.Containers[0].VolumeMounts).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("Restart on change handler", func() {
+	var (
+		mockCtrl *gomock.Controller
+		ctx      *mocks.MockContext
+		recorder *mocks.MockEventRecorder
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		ctx = mocks.NewMockContext(mockCtrl)
+		recorder = mocks.NewMockEventRecorder(mockCtrl)
+		ctx.EXPECT().EventRecorder().Return(recorder).AnyTimes()
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("should not touch applications if restart policy is never", func() {
+		ctx.EXPECT().RestartPolicy().Return(apis.RestartPolicyNever)
+		project.RestartOnChange(ctx)
+	})
+
+	It("should use valid annotation keys for long binding names", func() {
+		Expect(apis.BindingDataHashAnnotationKey("sb1")).To(Equal("data-hash.servicebinding.io/sb1"))
+
+		name := strings.Repeat("a", 253)
+		key := apis.BindingDataHashAnnotationKey(name)
+		Expect(validation.IsQualifiedName(key)).To(BeEmpty())
+		Expect(key).To(HavePrefix("data-hash.servicebinding.io/" + strings.Repeat("a", 54) + "-"))
+		Expect(apis.BindingDataHashAnnotationKey(name[:252] + "b")).NotTo(Equal(key))
+	})
+
+	Context("on change", func() {
+		var (
+			app      *mocks.MockApplication
+			template *pipeline.MetaPodSpec
+			u        map[string]interface{}
+		)
+
+		BeforeEach(func() {
+			var err error
+			u, err = runtime.DefaultUnstructuredConverter.ToUnstructured(deployment("d1", []corev1.Container{{Image: "foo"}}))
+			Expect(err).NotTo(HaveOccurred())
+			template = &pipeline.MetaPodSpec{
+				Annotations: strings.Split("spec.template.metadata.annotations", "."),
+				Data:        u,
+			}
+			app = mocks.NewMockApplication(mockCtrl)
+			ctx.EXPECT().RestartPolicy().Return(apis.RestartPolicyOnChange)
+			ctx.EXPECT().BindingName().Return("sb1")
+			ctx.EXPECT().BindingDataHash().Return("hash1")
+			ctx.EXPECT().Applications().Return([]pipeline.Application{app}, nil)
+		})
+
+		It("should stamp binding data hash into pod template annotations", func() {
+			app.EXPECT().BindablePods().Return(template, nil)
+			project.RestartOnChange(ctx)
+
+			d := &appsv1.Deployment{}
+			Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(u, d)).To(Succeed())
+			Expect(d.Spec.Template.Annotations).To(Equal(map[string]string{apis.BindingDataHashAnnotationKey("sb1"): "hash1"}))
+		})
+
+		It("should stop processing if annotations cannot be set", func() {
+			template.Annotations = nil
+			app.EXPECT().BindablePods().Return(template, nil)
+			app.EXPECT().SetError(gomock.Any())
+			recorder.EXPECT().WorkloadEvent(app, corev1.EventTypeWarning, project.InjectionErrorReason, gomock.Any())
+			ctx.EXPECT().FailurePolicy().Return(apis.FailurePolicyFailFast)
+			ctx.EXPECT().StopProcessing()
+			ctx.EXPECT().Error(gomock.Any())
+			ctx.EXPECT().SetCondition(gomock.Any())
+			project.RestartOnChange(ctx)
+		})
+	})
 })
 
 var _ = Describe("Unbind handler", func() {
 	var (
 		mockCtrl *gomock.Controller
 		ctx      *mocks.MockContext
+		recorder *mocks.MockEventRecorder
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		ctx = mocks.NewMockContext(mockCtrl)
+		recorder = mocks.NewMockEventRecorder(mockCtrl)
+		ctx.EXPECT().EventRecorder().Return(recorder).AnyTimes()
 	})
 
 	AfterEach(func() {
@@ -811,10 +963,11 @@ var _ = Describe("Unbind handler", func() {
 				deploymentsUnstructuredOld []*unstructured.Unstructured
 				secretName                 string
 				bindingName                string
+				apps                       []pipeline.Application
 			)
 
 			BeforeEach(func() {
-				var apps []pipeline.Application
+				apps = nil
 				secretName = "secret1"
 				bindingName = "binding1"
 				ctx.EXPECT().IsRemoved().AnyTimes().Return(true)
@@ -907,6 +1060,10 @@ var _ = Describe("Unbind handler", func() {
 						Image: "foo",
 					},
 				})
+				d7.Spec.Template.Annotations = map[string]string{
+					apis.BindingDataHashAnnotationKey(bindingName): "hash1",
+					"foo": "bar",
+				}
 				d7.Spec.Template.Spec.Volumes = []corev1.Volume{
 					{
 						Name: bindingName,
@@ -947,9 +1104,10 @@ var _ = Describe("Unbind handler", func() {
 						})
 					}
 					template := pipeline.MetaPodSpec{
-						Containers: metaContainers,
-						Volume:     strings.Split("spec.template.spec.volumes", "."),
-						Data:       u,
+						Containers:  metaContainers,
+						Volume:      strings.Split("spec.template.spec.volumes", "."),
+						Annotations: strings.Split("spec.template.metadata.annotations", "."),
+						Data:        u,
 					}
 					app.EXPECT().BindablePods().Return(&template, nil)
 					apps = append(apps, app)
@@ -1016,6 +1174,7 @@ var _ = Describe("Unbind handler", func() {
 						Image: "foo",
 					},
 				})
+				d7.Spec.Template.Annotations = map[string]string{"foo": "bar"}
 				d7.Spec.Template.Spec.Volumes = []corev1.Volume{
 					{
 						Name: "foo",
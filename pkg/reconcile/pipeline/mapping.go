@@ -34,8 +34,9 @@ type WorkloadContainer struct {
 }
 
 type WorkloadMapping struct {
-	Containers []WorkloadContainer
-	Volume     []string
+	Containers  []WorkloadContainer
+	Volume      []string
+	Annotations []string
 }
 
 func FromWorkloadResourceMappingTemplate(mapping v1beta1.ClusterWorkloadResourceMappingTemplate) (*WorkloadMapping, error) {
@@ -71,7 +72,12 @@ func FromWorkloadResourceMappingTemplate(mapping v1beta1.ClusterWorkloadResource
 		return nil, err
 	}
 
-	return &WorkloadMapping{Containers: containers, Volume: volume}, nil
+	var annotations []string
+	if err := constructRestrictedPath(mapping.Annotations, []string{"spec", "template", "metadata", "annotations"}, &annotations); err != nil {
+		return nil, err
+	}
+
+	return &WorkloadMapping{Containers: containers, Volume: volume, Annotations: annotations}, nil
 }
 
 func constructRestrictedPath(value string, defaultValue []string, target *[]string) error {
@@ -379,3 +385,19 @@ func (template *MetaPodSpec) RemoveVolume(name string) error {
 	}
 	return nil
 }
+
+func (template *MetaPodSpec) SetAnnotation(key string, value string) error {
+	if len(template.Annotations) == 0 {
+		return fmt.Errorf("No annotations field")
+	}
+	path := append(append([]string{}, template.Annotations...), key)
+	return unstructured.SetNestedField(template.Data, value, path...)
+}
+
+func (template *MetaPodSpec) RemoveAnnotation(key string) {
+	if len(template.Annotations) == 0 {
+		return
+	}
+	path := append(append([]string{}, template.Annotations...), key)
+	unstructured.RemoveNestedField(template.Data, path...)
+}
@@ -78,6 +78,7 @@ var _ = Describe("Mapping workloads", func() {
 			result, err := pipeline.FromWorkloadResourceMappingTemplate(template)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result.Volume).To(Equal(mapping.Volume))
+			Expect(result.Annotations).To(Equal([]string{"spec", "annotations"}))
 			Expect(result.Containers[0].Name).To(Equal(mapping.Containers[0].Name))
 			Expect(result.Containers[0].Env).To(Equal(mapping.Containers[0].Env))
 			Expect(result.Containers[0].EnvFrom).To(Equal(mapping.Containers[0].EnvFrom))
@@ -117,6 +118,26 @@ var _ = Describe("Mapping workloads", func() {
 			Expect(result.Containers[0].VolumeMounts).To(Equal(mapping.Containers[0].VolumeMounts))
 		})
 
+		It("should use pod template annotations by default", func() {
+			template := v1beta1.ClusterWorkloadResourceMappingTemplate{
+				Version: "*",
+				Containers: []v1beta1.ClusterWorkloadResourceMappingContainer{
+					{
+						Path: ".spec.spec.containers[*]",
+					},
+				},
+			}
+
+			result, err := pipeline.FromWorkloadResourceMappingTemplate(template)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Annotations).To(Equal([]string{"spec", "template", "metadata", "annotations"}))
+			Expect(result.Volume).To(Equal([]string{"spec", "template", "spec", "volumes"}))
+
+			result, err = pipeline.FromWorkloadResourceMappingTemplate(v1beta1.DefaultTemplate)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Annotations).To(Equal([]string{"spec", "template", "metadata", "annotations"}))
+		})
+
 		It("should return an error on invalid mappings", func() {
 			template := v1beta1.ClusterWorkloadResourceMappingTemplate{
 				Version:     "*",
@@ -665,4 +686,74 @@ var _ = Describe("Mapping workloads", func() {
 			}))
 		})
 	})
+
+	Context("Annotations", func() {
+		It("should set annotations", func() {
+			template := pipeline.MetaPodSpec{
+				Annotations: []string{"spec", "template", "metadata", "annotations"},
+				Data: map[string]interface{}{
+					"spec": map[string]interface{}{
+						"template": map[string]interface{}{
+							"metadata": map[string]interface{}{
+								"annotations": map[string]interface{}{
+									"foo": "bar",
+								},
+							},
+						},
+					},
+				},
+			}
+
+			Expect(template.SetAnnotation("spam", "eggs")).To(Succeed())
+			Expect(template.Data).To(Equal(map[string]interface{}{
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"metadata": map[string]interface{}{
+							"annotations": map[string]interface{}{
+								"foo":  "bar",
+								"spam": "eggs",
+							},
+						},
+					},
+				},
+			}))
+
+			template.RemoveAnnotation("foo")
+			Expect(template.Data).To(Equal(map[string]interface{}{
+				"spec": map[string]interface{}{
+					"template": map[string]interface{}{
+						"metadata": map[string]interface{}{
+							"annotations": map[string]interface{}{
+								"spam": "eggs",
+							},
+						},
+					},
+				},
+			}))
+		})
+
+		It("should create missing annotations", func() {
+			template := pipeline.MetaPodSpec{
+				Annotations: []string{"metadata", "annotations"},
+				Data:        map[string]interface{}{},
+			}
+
+			Expect(template.SetAnnotation("spam", "eggs")).To(Succeed())
+			Expect(template.Data).To(Equal(map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"spam": "eggs",
+					},
+				},
+			}))
+		})
+
+		It("should fail setting annotations without annotations path", func() {
+			template := pipeline.MetaPodSpec{
+				Data: map[string]interface{}{},
+			}
+
+			Expect(template.SetAnnotation("spam", "eggs")).NotTo(Succeed())
+		})
+	})
 })
@@ -1,13 +1,15 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline (interfaces: Context,Service,CRD,Application,ContextProvider,Handler)
+// Source: github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline (interfaces: Context,Service,CRD,Application,ContextProvider,Handler,EventRecorder)
 
 // Package mocks is a generated GoMock package.
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	apis "github.com/redhat-developer/service-binding-operator/apis"
 	binding "github.com/redhat-developer/service-binding-operator/pkg/binding"
 	pipeline "github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -77,6 +79,20 @@ func (mr *MockContextMockRecorder) Applications() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Applications", reflect.TypeOf((*MockContext)(nil).Applications))
 }
 
+// AuthorizeCrossNamespaceReference mocks base method.
+func (m *MockContext) AuthorizeCrossNamespaceReference(arg0 apis.CrossNamespaceReference) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeCrossNamespaceReference", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AuthorizeCrossNamespaceReference indicates an expected call of AuthorizeCrossNamespaceReference.
+func (mr *MockContextMockRecorder) AuthorizeCrossNamespaceReference(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeCrossNamespaceReference", reflect.TypeOf((*MockContext)(nil).AuthorizeCrossNamespaceReference), arg0)
+}
+
 // BindAsFiles mocks base method.
 func (m *MockContext) BindAsFiles() bool {
 	m.ctrl.T.Helper()
@@ -91,6 +107,20 @@ func (mr *MockContextMockRecorder) BindAsFiles() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindAsFiles", reflect.TypeOf((*MockContext)(nil).BindAsFiles))
 }
 
+// BindingDataHash mocks base method.
+func (m *MockContext) BindingDataHash() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BindingDataHash")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// BindingDataHash indicates an expected call of BindingDataHash.
+func (mr *MockContextMockRecorder) BindingDataHash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BindingDataHash", reflect.TypeOf((*MockContext)(nil).BindingDataHash))
+}
+
 // BindingItems mocks base method.
 func (m *MockContext) BindingItems() pipeline.BindingItems {
 	m.ctrl.T.Helper()
@@ -199,6 +229,34 @@ func (mr *MockContextMockRecorder) Error(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Error", reflect.TypeOf((*MockContext)(nil).Error), arg0)
 }
 
+// EventRecorder mocks base method.
+func (m *MockContext) EventRecorder() pipeline.EventRecorder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventRecorder")
+	ret0, _ := ret[0].(pipeline.EventRecorder)
+	return ret0
+}
+
+// EventRecorder indicates an expected call of EventRecorder.
+func (mr *MockContextMockRecorder) EventRecorder() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventRecorder", reflect.TypeOf((*MockContext)(nil).EventRecorder))
+}
+
+// FailurePolicy mocks base method.
+func (m *MockContext) FailurePolicy() apis.FailurePolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FailurePolicy")
+	ret0, _ := ret[0].(apis.FailurePolicy)
+	return ret0
+}
+
+// FailurePolicy indicates an expected call of FailurePolicy.
+func (mr *MockContextMockRecorder) FailurePolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FailurePolicy", reflect.TypeOf((*MockContext)(nil).FailurePolicy))
+}
+
 // FlowStatus mocks base method.
 func (m *MockContext) FlowStatus() pipeline.FlowStatus {
 	m.ctrl.T.Helper()
@@ -313,6 +371,20 @@ func (mr *MockContextMockRecorder) ReadSecret(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSecret", reflect.TypeOf((*MockContext)(nil).ReadSecret), arg0, arg1)
 }
 
+// RestartPolicy mocks base method.
+func (m *MockContext) RestartPolicy() apis.RestartPolicy {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestartPolicy")
+	ret0, _ := ret[0].(apis.RestartPolicy)
+	return ret0
+}
+
+// RestartPolicy indicates an expected call of RestartPolicy.
+func (mr *MockContextMockRecorder) RestartPolicy() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestartPolicy", reflect.TypeOf((*MockContext)(nil).RestartPolicy))
+}
+
 // RetryProcessing mocks base method.
 func (m *MockContext) RetryProcessing(arg0 error) {
 	m.ctrl.T.Helper()
@@ -352,6 +424,18 @@ func (mr *MockContextMockRecorder) SetCondition(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCondition", reflect.TypeOf((*MockContext)(nil).SetCondition), arg0)
 }
 
+// SetRequestContext mocks base method.
+func (m *MockContext) SetRequestContext(arg0 context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRequestContext", arg0)
+}
+
+// SetRequestContext indicates an expected call of SetRequestContext.
+func (mr *MockContextMockRecorder) SetRequestContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRequestContext", reflect.TypeOf((*MockContext)(nil).SetRequestContext), arg0)
+}
+
 // StopProcessing mocks base method.
 func (m *MockContext) StopProcessing() {
 	m.ctrl.T.Helper()
@@ -538,6 +622,21 @@ func (m *MockCRD) EXPECT() *MockCRDMockRecorder {
 	return m.recorder
 }
 
+// Descriptor mocks base method.
+func (m *MockCRD) Descriptor() (*pipeline.CRDDescription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Descriptor")
+	ret0, _ := ret[0].(*pipeline.CRDDescription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Descriptor indicates an expected call of Descriptor.
+func (mr *MockCRDMockRecorder) Descriptor() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Descriptor", reflect.TypeOf((*MockCRD)(nil).Descriptor))
+}
+
 // IsBindable mocks base method.
 func (m *MockCRD) IsBindable() (bool, error) {
 	m.ctrl.T.Helper()
@@ -590,6 +689,18 @@ func (m *MockApplication) EXPECT() *MockApplicationMockRecorder {
 	return m.recorder
 }
 
+// AddBoundContainer mocks base method.
+func (m *MockApplication) AddBoundContainer(arg0, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddBoundContainer", arg0, arg1)
+}
+
+// AddBoundContainer indicates an expected call of AddBoundContainer.
+func (mr *MockApplicationMockRecorder) AddBoundContainer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBoundContainer", reflect.TypeOf((*MockApplication)(nil).AddBoundContainer), arg0, arg1)
+}
+
 // BindablePods mocks base method.
 func (m *MockApplication) BindablePods() (*pipeline.MetaPodSpec, error) {
 	m.ctrl.T.Helper()
@@ -661,6 +772,18 @@ func (mr *MockApplicationMockRecorder) SecretPath() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecretPath", reflect.TypeOf((*MockApplication)(nil).SecretPath))
 }
 
+// SetError mocks base method.
+func (m *MockApplication) SetError(arg0 error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetError", arg0)
+}
+
+// SetError indicates an expected call of SetError.
+func (mr *MockApplicationMockRecorder) SetError(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetError", reflect.TypeOf((*MockApplication)(nil).SetError), arg0)
+}
+
 // SetMapping mocks base method.
 func (m *MockApplication) SetMapping(arg0 pipeline.WorkloadMapping) {
 	m.ctrl.T.Helper()
@@ -745,3 +868,50 @@ func (mr *MockHandlerMockRecorder) Handle(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockHandler)(nil).Handle), arg0)
 }
+
+// MockEventRecorder is a mock of EventRecorder interface.
+type MockEventRecorder struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRecorderMockRecorder
+}
+
+// MockEventRecorderMockRecorder is the mock recorder for MockEventRecorder.
+type MockEventRecorderMockRecorder struct {
+	mock *MockEventRecorder
+}
+
+// NewMockEventRecorder creates a new mock instance.
+func NewMockEventRecorder(ctrl *gomock.Controller) *MockEventRecorder {
+	mock := &MockEventRecorder{ctrl: ctrl}
+	mock.recorder = &MockEventRecorderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRecorder) EXPECT() *MockEventRecorderMockRecorder {
+	return m.recorder
+}
+
+// Event mocks base method.
+func (m *MockEventRecorder) Event(arg0, arg1, arg2 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Event", arg0, arg1, arg2)
+}
+
+// Event indicates an expected call of Event.
+func (mr *MockEventRecorderMockRecorder) Event(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Event", reflect.TypeOf((*MockEventRecorder)(nil).Event), arg0, arg1, arg2)
+}
+
+// WorkloadEvent mocks base method.
+func (m *MockEventRecorder) WorkloadEvent(arg0 pipeline.Application, arg1, arg2, arg3 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "WorkloadEvent", arg0, arg1, arg2, arg3)
+}
+
+// WorkloadEvent indicates an expected call of WorkloadEvent.
+func (mr *MockEventRecorderMockRecorder) WorkloadEvent(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WorkloadEvent", reflect.TypeOf((*MockEventRecorder)(nil).WorkloadEvent), arg0, arg1, arg2, arg3)
+}
@@ -1,7 +1,7 @@
 package pipeline
 
 import (
-	"encoding/base64"
+	"github.com/redhat-developer/service-binding-operator/pkg/binding"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -23,19 +23,15 @@ func (s *SecretBackedBindings) Items() (BindingItems, error) {
 	if s.items != nil {
 		return s.items, nil
 	}
-	data, found, err := unstructured.NestedStringMap(s.Secret.Object, "data")
+	data, found, err := binding.SecretEntries(s.Secret)
 	if err != nil {
 		return nil, err
 	}
 	if found {
 		for k, v := range data {
-			val, err := base64.StdEncoding.DecodeString(v)
-			if err != nil {
-				return nil, err
-			}
 			s.items = append(s.items, &BindingItem{
 				Name:   k,
-				Value:  string(val),
+				Value:  v,
 				Source: s.Service,
 			})
 		}
@@ -37,5 +37,28 @@ var _ = Describe("Binding Items", func() {
 			map[string]string{
 				"foo2": "2",
 			}, &pipeline.BindingItem{Name: "foo2", Value: 2}),
+		Entry("entry with binary value",
+			map[string]string{
+				"foo": "\xde\xad",
+			}, &pipeline.BindingItem{Name: "foo", Value: []byte{0xde, 0xad}}),
+		Entry("entries with structured values",
+			map[string]string{
+				"map":   `{"a":"b","c":1}`,
+				"slice": `["a","b"]`,
+			},
+			&pipeline.BindingItem{Name: "map", Value: map[string]interface{}{"c": int64(1), "a": "b"}},
+			&pipeline.BindingItem{Name: "slice", Value: []interface{}{"a", "b"}}),
+	)
+
+	DescribeTable("binding item value type", func(value interface{}, expected pipeline.ValueType) {
+		item := &pipeline.BindingItem{Name: "foo", Value: value}
+		Expect(item.Type()).To(Equal(expected))
+	},
+		Entry("string", "bar", pipeline.StringValue),
+		Entry("number", int64(1), pipeline.StringValue),
+		Entry("bytes", []byte("bar"), pipeline.BytesValue),
+		Entry("map", map[string]interface{}{"a": "b"}, pipeline.StructuredValue),
+		Entry("slice", []interface{}{"a"}, pipeline.StructuredValue),
+		Entry("slice of strings", []string{"a"}, pipeline.StructuredValue),
 	)
 })
@@ -0,0 +1,73 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ServiceName identifies the operator in exported traces
+	ServiceName = "service-binding-operator"
+
+	// NoExporter disables tracing
+	NoExporter = ""
+
+	// OTLPExporter exports spans to an OpenTelemetry collector, reachable at the endpoint given by the
+	// standard OTEL_EXPORTER_OTLP_ENDPOINT environment variable, localhost:4317 by default
+	OTLPExporter = "otlp"
+
+	// StdoutExporter writes spans to the standard output
+	StdoutExporter = "stdout"
+)
+
+const tracerName = "github.com/redhat-developer/service-binding-operator"
+
+// Tracer returns the tracer used to create spans about the processing of bindings
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Setup registers a global tracer provider exporting spans with the given exporter. The returned
+// function flushes the pending spans and shuts the provider down.
+func Setup(ctx context.Context, exporter string) (func(context.Context) error, error) {
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case NoExporter:
+		return func(context.Context) error { return nil }, nil
+	case OTLPExporter:
+		spanExporter, err = otlptracegrpc.New(ctx)
+	case StdoutExporter:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, expected one of %q or %q", exporter, OTLPExporter, StdoutExporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// WrapTransport creates a span for each request sent to the API server, as a child of the span
+// carried by the context of the request
+func WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(rt)
+}
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tracing setup", func() {
+
+	AfterEach(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+
+	It("should not record spans if no exporter is configured", func() {
+		shutdown, err := Setup(context.Background(), NoExporter)
+		Expect(err).NotTo(HaveOccurred())
+		_, span := Tracer().Start(context.Background(), "foo")
+		Expect(span.IsRecording()).To(BeFalse())
+		Expect(shutdown(context.Background())).To(Succeed())
+	})
+
+	It("should record spans exported to the standard output", func() {
+		shutdown, err := Setup(context.Background(), StdoutExporter)
+		Expect(err).NotTo(HaveOccurred())
+		_, span := Tracer().Start(context.Background(), "foo")
+		Expect(span.IsRecording()).To(BeTrue())
+		span.End()
+		Expect(shutdown(context.Background())).To(Succeed())
+	})
+
+	It("should fail on unknown exporter", func() {
+		_, err := Setup(context.Background(), "foo")
+		Expect(err).To(MatchError(`unknown tracing exporter "foo", expected one of "otlp" or "stdout"`))
+	})
+})
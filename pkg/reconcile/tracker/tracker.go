@@ -0,0 +1,274 @@
+package tracker
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	authv1 "k8s.io/client-go/kubernetes/typed/authorization/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var _ pipeline.ResourceTracker = &Tracker{}
+
+type resourceKey struct {
+	groupResource schema.GroupResource
+	namespace     string
+	name          string
+}
+
+// Tracker indexes resources read while processing bindings, e.g. services and secrets/configmaps
+// referred from their binding definitions, and dynamically watches their kinds. Whenever a tracked
+// resource changes, only bindings depending on it are enqueued for reconciliation.
+type Tracker struct {
+	lock sync.Mutex
+
+	log          logr.Logger
+	cache        cache.Cache
+	restMapper   meta.RESTMapper
+	accessReview authv1.SelfSubjectAccessReviewInterface
+
+	controller controller.Controller
+
+	// group resources with already established watches, value is false if the operator is not allowed to watch them
+	watched map[schema.GroupResource]bool
+
+	// bindings depending on the given resource
+	dependents map[resourceKey]sets.Set[types.NamespacedName]
+
+	// resources the given binding depends on
+	dependencies map[types.NamespacedName]sets.Set[resourceKey]
+
+	// label selectors of bindings depending on all matching resources of the given type
+	selectors map[schema.GroupResource]map[types.NamespacedName]selectorReference
+}
+
+type selectorReference struct {
+	namespace string
+	selector  labels.Selector
+}
+
+// New creates a tracker using the given cache for watches and access reviews to skip resources that cannot be watched
+func New(log logr.Logger, cache cache.Cache, restMapper meta.RESTMapper, accessReview authv1.SelfSubjectAccessReviewInterface) *Tracker {
+	return &Tracker{
+		log:          log,
+		cache:        cache,
+		restMapper:   restMapper,
+		accessReview: accessReview,
+		watched:      make(map[schema.GroupResource]bool),
+		dependents:   make(map[resourceKey]sets.Set[types.NamespacedName]),
+		dependencies: make(map[types.NamespacedName]sets.Set[resourceKey]),
+		selectors:    make(map[schema.GroupResource]map[types.NamespacedName]selectorReference),
+	}
+}
+
+// SetController sets the controller reconciling tracked bindings, watches are added to it
+func (t *Tracker) SetController(c controller.Controller) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.controller = c
+}
+
+// Track replaces the resources the given binding depends on, and returns those that cannot be watched
+func (t *Tracker) Track(binding types.NamespacedName, resources []pipeline.ResourceReference) []pipeline.ResourceReference {
+	access := t.reviewAccess(resources)
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for key := range t.dependencies[binding] {
+		t.dependents[key].Delete(binding)
+		if t.dependents[key].Len() == 0 {
+			delete(t.dependents, key)
+		}
+	}
+	delete(t.dependencies, binding)
+	for gr, bindings := range t.selectors {
+		delete(bindings, binding)
+		if len(bindings) == 0 {
+			delete(t.selectors, gr)
+		}
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+
+	var unwatched []pipeline.ResourceReference
+	keys := sets.New[resourceKey]()
+	for _, r := range resources {
+		if !t.ensureWatch(r.GroupVersionResource, access) {
+			unwatched = append(unwatched, r)
+			continue
+		}
+		gr := r.GroupVersionResource.GroupResource()
+		if r.Selector != nil {
+			if _, ok := t.selectors[gr]; !ok {
+				t.selectors[gr] = make(map[types.NamespacedName]selectorReference)
+			}
+			t.selectors[gr][binding] = selectorReference{namespace: r.Namespace, selector: r.Selector}
+			continue
+		}
+		key := resourceKey{groupResource: gr, namespace: r.Namespace, name: r.Name}
+		keys.Insert(key)
+		if _, ok := t.dependents[key]; !ok {
+			t.dependents[key] = sets.New[types.NamespacedName]()
+		}
+		t.dependents[key].Insert(binding)
+	}
+	t.dependencies[binding] = keys
+	return unwatched
+}
+
+// reviewAccess reviews whether resources of the given types not watched yet can be watched. Access reviews
+// are made without holding the lock, so that events of watched resources are handled meanwhile. Types whose
+// access could not be reviewed are missing from the result.
+func (t *Tracker) reviewAccess(resources []pipeline.ResourceReference) map[schema.GroupResource]bool {
+	t.lock.Lock()
+	var pending []schema.GroupVersionResource
+	if t.controller != nil {
+		for _, r := range resources {
+			if _, found := t.watched[r.GroupVersionResource.GroupResource()]; !found {
+				pending = append(pending, r.GroupVersionResource)
+			}
+		}
+	}
+	t.lock.Unlock()
+
+	result := make(map[schema.GroupResource]bool)
+	for _, gvr := range pending {
+		gr := gvr.GroupResource()
+		if _, reviewed := result[gr]; reviewed {
+			continue
+		}
+		allowed, err := t.canWatch(gvr)
+		if err != nil {
+			t.log.Error(err, "Unable to review self subject access", "resource", gr)
+			continue
+		}
+		result[gr] = allowed
+	}
+	return result
+}
+
+// ensureWatch starts watching resources of the given type if not already watched and the given access reviews
+// allow it, it returns false if resources of such type cannot be watched. Only denied access is remembered,
+// watches failing for other reasons, e.g. kinds not installed yet, are attempted again on the next call.
+func (t *Tracker) ensureWatch(gvr schema.GroupVersionResource, access map[schema.GroupResource]bool) bool {
+	gr := gvr.GroupResource()
+	if ok, found := t.watched[gr]; found {
+		// the type might have been resolved by another binding while access was reviewed
+		return ok
+	}
+	if t.controller == nil {
+		return false
+	}
+	log := t.log.WithValues("resource", gr)
+	allowed, reviewed := access[gr]
+	if !reviewed {
+		return false
+	}
+	if !allowed {
+		t.watched[gr] = false
+		log.Info("Not allowed to watch resources, bindings selecting them by labels are reprocessed periodically, bindings referring to them by name are not reprocessed on their change")
+		return false
+	}
+	gvk, err := t.restMapper.KindFor(gvr)
+	if err != nil {
+		log.Error(err, "Unable to find kind of resource")
+		return false
+	}
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gvk)
+	err = t.controller.Watch(source.Kind(t.cache, obj), t.eventHandler(gr))
+	if err != nil {
+		log.Error(err, "Unable to watch resources")
+		return false
+	}
+	log.Info("Watching resources")
+	t.watched[gr] = true
+	return true
+}
+
+func (t *Tracker) canWatch(gvr schema.GroupVersionResource) (bool, error) {
+	for _, verb := range []string{"list", "watch"} {
+		review, err := t.accessReview.Create(context.Background(), &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Verb:     verb,
+					Group:    gvr.Group,
+					Version:  gvr.Version,
+					Resource: gvr.Resource,
+				},
+			},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		if !review.Status.Allowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// eventHandler enqueues bindings depending on changed resources of the given type. Bindings selecting
+// resources by labels are enqueued only when a matching resource is created, relabeled or its spec changes,
+// e.g. when the pod template of a workload gets reset
+func (t *Tracker) eventHandler(gr schema.GroupResource) handler.EventHandler {
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q, t.bindingsFor(gr, e.Object, true))
+		},
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			if e.ObjectOld.GetResourceVersion() == e.ObjectNew.GetResourceVersion() {
+				return
+			}
+			selected := e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration() ||
+				!labels.Equals(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+			enqueue(q, t.bindingsFor(gr, e.ObjectOld, selected))
+			enqueue(q, t.bindingsFor(gr, e.ObjectNew, selected))
+		},
+		DeleteFunc: func(_ context.Context, e event.DeleteEvent, q workqueue.RateLimitingInterface) {
+			enqueue(q, t.bindingsFor(gr, e.Object, false))
+		},
+	}
+}
+
+func enqueue(q workqueue.RateLimitingInterface, bindings sets.Set[types.NamespacedName]) {
+	for binding := range bindings {
+		q.Add(reconcile.Request{NamespacedName: binding})
+	}
+}
+
+// bindingsFor returns bindings depending on the given resource, including those selecting it
+// by labels if selected is true
+func (t *Tracker) bindingsFor(gr schema.GroupResource, obj client.Object, selected bool) sets.Set[types.NamespacedName] {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	result := sets.New[types.NamespacedName]()
+	if bindings, ok := t.dependents[resourceKey{groupResource: gr, namespace: obj.GetNamespace(), name: obj.GetName()}]; ok {
+		result.Insert(bindings.UnsortedList()...)
+	}
+	if selected {
+		for binding, ref := range t.selectors[gr] {
+			if ref.namespace == obj.GetNamespace() && ref.selector.Matches(labels.Set(obj.GetLabels())) {
+				result.Insert(binding)
+			}
+		}
+	}
+	return result
+}
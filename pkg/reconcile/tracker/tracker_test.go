@@ -0,0 +1,309 @@
+package tracker
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/redhat-developer/service-binding-operator/pkg/reconcile/pipeline"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakeauth "k8s.io/client-go/kubernetes/typed/authorization/v1/fake"
+	"k8s.io/client-go/testing"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+var _ = Describe("Tracker", func() {
+
+	var (
+		tracker    *Tracker
+		controller *fakeController
+		restMapper *meta.DefaultRESTMapper
+		allowed    map[string]bool
+		reviews    int
+		locked     bool
+
+		secretsGVR = schema.GroupVersionResource{Version: "v1", Resource: "secrets"}
+		barsGVR    = schema.GroupVersionResource{Group: "foo", Version: "v1", Resource: "bars"}
+
+		sb1 = types.NamespacedName{Namespace: "ns1", Name: "sb1"}
+		sb2 = types.NamespacedName{Namespace: "ns1", Name: "sb2"}
+	)
+
+	var (
+		object = func(namespace string, name string, lbls map[string]string) *metav1.PartialObjectMetadata {
+			obj := &metav1.PartialObjectMetadata{}
+			obj.SetNamespace(namespace)
+			obj.SetName(name)
+			obj.SetLabels(lbls)
+			obj.SetResourceVersion("1")
+			obj.SetGeneration(1)
+			return obj
+		}
+		dependents = func(gvr schema.GroupVersionResource, namespace string, name string) []types.NamespacedName {
+			return tracker.bindingsFor(gvr.GroupResource(), object(namespace, name, nil), false).UnsortedList()
+		}
+	)
+
+	BeforeEach(func() {
+		allowed = map[string]bool{"secrets": true, "bars": true}
+		reviews = 0
+		locked = false
+		authClient := &fakeauth.FakeAuthorizationV1{Fake: &testing.Fake{}}
+		authClient.AddReactor("create", "selfsubjectaccessreviews", func(action testing.Action) (bool, runtime.Object, error) {
+			reviews++
+			if tracker.lock.TryLock() {
+				tracker.lock.Unlock()
+			} else {
+				locked = true
+			}
+			review := action.(testing.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+			review.Status.Allowed = allowed[review.Spec.ResourceAttributes.Resource]
+			return true, review, nil
+		})
+		restMapper = meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, meta.RESTScopeNamespace)
+		restMapper.Add(schema.GroupVersionKind{Group: "foo", Version: "v1", Kind: "Bar"}, meta.RESTScopeNamespace)
+		controller = &fakeController{}
+		tracker = New(logr.Discard(), nil, restMapper, authClient.SelfSubjectAccessReviews())
+		tracker.SetController(controller)
+	})
+
+	It("should watch each tracked resource type once", func() {
+		tracker.Track(sb1, []pipeline.ResourceReference{
+			{GroupVersionResource: secretsGVR, Namespace: "ns1", Name: "s1"},
+			{GroupVersionResource: barsGVR, Namespace: "ns1", Name: "b1"},
+		})
+		tracker.Track(sb2, []pipeline.ResourceReference{
+			{GroupVersionResource: secretsGVR, Namespace: "ns1", Name: "s2"},
+		})
+
+		Expect(controller.watches).To(Equal(2))
+		Expect(reviews).To(Equal(4))
+	})
+
+	It("should review access without holding the lock", func() {
+		tracker.Track(sb1, []pipeline.ResourceReference{
+			{GroupVersionResource: secretsGVR, Namespace: "ns1", Name: "s1"},
+		})
+
+		Expect(reviews).To(Equal(2))
+		Expect(locked).To(BeFalse())
+		Expect(controller.watches).To(Equal(1))
+	})
+
+	It("should return bindings depending on changed resource", func() {
+		tracker.Track(sb1, []pipeline.ResourceReference{
+			{GroupVersionResource: secretsGVR, Namespace: "ns1", Name: "s1"},
+		})
+		tracker.Track(sb2, []pipeline.ResourceReference{
+			{GroupVersionResource: secretsGVR, Namespace: "ns1", Name: "s1"},
+			{GroupVersionResource: barsGVR, Namespace: "ns1", Name: "b1"},
+		})
+
+		Expect(dependents(secretsGVR, "ns1", "s1")).To(ConsistOf(
+			sb1,
+			sb2,
+		))
+		Expect(dependents(barsGVR, "ns1", "b1")).To(ConsistOf(
+			sb2,
+		))
+		Expect(dependents(secretsGVR, "ns2", "s1")).To(BeEmpty())
+	})
+
+	It("should replace dependencies of tracked binding", func() {
+		tracker.Track(sb1, []pipeline.ResourceReference{
+			{GroupVersionResource: secretsGVR, Namespace: "ns1", Name: "s1"},
+		})
+		tracker.Track(sb1, []pipeline.ResourceReference{
+			{GroupVersionResource: secretsGVR, Namespace: "ns1", Name: "s2"},
+		})
+
+		Expect(dependents(secretsGVR, "ns1", "s1")).To(BeEmpty())
+		Expect(dependents(secretsGVR, "ns1", "s2")).To(ConsistOf(
+			sb1,
+		))
+	})
+
+	It("should stop tracking binding when no resources are given", func() {
+		tracker.Track(sb1, []pipeline.ResourceReference{
+			{GroupVersionResource: secretsGVR, Namespace: "ns1", Name: "s1"},
+		})
+		tracker.Track(sb1, nil)
+
+		Expect(dependents(secretsGVR, "ns1", "s1")).To(BeEmpty())
+		Expect(tracker.dependents).To(BeEmpty())
+		Expect(tracker.dependencies).To(BeEmpty())
+	})
+
+	It("should not track resources which cannot be watched", func() {
+		allowed["bars"] = false
+		tracker.Track(sb1, []pipeline.ResourceReference{
+			{GroupVersionResource: barsGVR, Namespace: "ns1", Name: "b1"},
+		})
+		tracker.Track(sb2, []pipeline.ResourceReference{
+			{GroupVersionResource: barsGVR, Namespace: "ns1", Name: "b1"},
+		})
+
+		Expect(controller.watches).To(Equal(0))
+		Expect(reviews).To(Equal(1))
+		Expect(dependents(barsGVR, "ns1", "b1")).To(BeEmpty())
+	})
+
+	It("should return resources which cannot be watched", func() {
+		allowed["bars"] = false
+		selected := pipeline.ResourceReference{GroupVersionResource: barsGVR, Namespace: "ns1", Selector: labels.SelectorFromSet(map[string]string{"env": "prod"})}
+		unwatched := tracker.Track(sb1, []pipeline.ResourceReference{
+			{GroupVersionResource: secretsGVR, Namespace: "ns1", Name: "s1"},
+			selected,
+		})
+
+		Expect(unwatched).To(Equal([]pipeline.ResourceReference{selected}))
+		Expect(tracker.Track(sb1, nil)).To(BeEmpty())
+	})
+
+	It("should attempt to watch resources again if watching them failed for other reason than denied access", func() {
+		bazsGVR := schema.GroupVersionResource{Group: "foo", Version: "v1", Resource: "bazs"}
+		allowed["bazs"] = true
+		refs := []pipeline.ResourceReference{
+			{GroupVersionResource: bazsGVR, Namespace: "ns1", Name: "b1"},
+		}
+		Expect(tracker.Track(sb1, refs)).To(Equal(refs))
+		Expect(controller.watches).To(Equal(0))
+
+		restMapper.Add(schema.GroupVersionKind{Group: "foo", Version: "v1", Kind: "Baz"}, meta.RESTScopeNamespace)
+		Expect(tracker.Track(sb1, refs)).To(BeEmpty())
+		Expect(controller.watches).To(Equal(1))
+		Expect(reviews).To(Equal(4))
+		Expect(dependents(bazsGVR, "ns1", "b1")).To(ConsistOf(sb1))
+	})
+
+	Describe("event handler", func() {
+		var (
+			queue   workqueue.RateLimitingInterface
+			handler handler.EventHandler
+			prod    = map[string]string{"env": "prod"}
+
+			enqueued = func() []types.NamespacedName {
+				var result []types.NamespacedName
+				for queue.Len() > 0 {
+					item, _ := queue.Get()
+					result = append(result, item.(reconcile.Request).NamespacedName)
+					queue.Done(item)
+				}
+				return result
+			}
+			updated = func(obj *metav1.PartialObjectMetadata, change func(o *metav1.PartialObjectMetadata)) *metav1.PartialObjectMetadata {
+				result := obj.DeepCopy()
+				result.SetResourceVersion("2")
+				change(result)
+				return result
+			}
+		)
+
+		BeforeEach(func() {
+			queue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			handler = tracker.eventHandler(barsGVR.GroupResource())
+			tracker.Track(sb1, []pipeline.ResourceReference{
+				{GroupVersionResource: barsGVR, Namespace: "ns1", Selector: labels.SelectorFromSet(prod)},
+			})
+			tracker.Track(sb2, []pipeline.ResourceReference{
+				{GroupVersionResource: barsGVR, Namespace: "ns1", Name: "b1"},
+			})
+		})
+
+		AfterEach(func() {
+			queue.ShutDown()
+		})
+
+		It("should enqueue bindings selecting created resource", func() {
+			handler.Create(context.Background(), event.CreateEvent{Object: object("ns1", "b2", prod)}, queue)
+			Expect(enqueued()).To(ConsistOf(sb1))
+
+			handler.Create(context.Background(), event.CreateEvent{Object: object("ns2", "b2", prod)}, queue)
+			handler.Create(context.Background(), event.CreateEvent{Object: object("ns1", "b2", map[string]string{"env": "dev"})}, queue)
+			Expect(enqueued()).To(BeEmpty())
+		})
+
+		It("should enqueue bindings selecting relabeled resource", func() {
+			obj := object("ns1", "b2", nil)
+			handler.Update(context.Background(), event.UpdateEvent{ObjectOld: obj, ObjectNew: updated(obj, func(o *metav1.PartialObjectMetadata) {
+				o.SetLabels(prod)
+			})}, queue)
+			Expect(enqueued()).To(ConsistOf(sb1))
+		})
+
+		It("should enqueue bindings selecting resource with changed spec", func() {
+			obj := object("ns1", "b2", prod)
+			handler.Update(context.Background(), event.UpdateEvent{ObjectOld: obj, ObjectNew: updated(obj, func(o *metav1.PartialObjectMetadata) {
+				o.SetGeneration(2)
+			})}, queue)
+			Expect(enqueued()).To(ConsistOf(sb1))
+		})
+
+		It("should not enqueue bindings selecting resource with changed status only", func() {
+			obj := object("ns1", "b2", prod)
+			handler.Update(context.Background(), event.UpdateEvent{ObjectOld: obj, ObjectNew: updated(obj, func(o *metav1.PartialObjectMetadata) {})}, queue)
+			handler.Delete(context.Background(), event.DeleteEvent{Object: obj}, queue)
+			Expect(enqueued()).To(BeEmpty())
+		})
+
+		It("should enqueue bindings referring resource by name on any change", func() {
+			obj := object("ns1", "b1", prod)
+			handler.Update(context.Background(), event.UpdateEvent{ObjectOld: obj, ObjectNew: updated(obj, func(o *metav1.PartialObjectMetadata) {})}, queue)
+			Expect(enqueued()).To(ConsistOf(sb2))
+
+			handler.Delete(context.Background(), event.DeleteEvent{Object: obj}, queue)
+			Expect(enqueued()).To(ConsistOf(sb2))
+		})
+
+		It("should not enqueue bindings after they stop tracking", func() {
+			tracker.Track(sb1, nil)
+			handler.Create(context.Background(), event.CreateEvent{Object: object("ns1", "b2", prod)}, queue)
+			Expect(enqueued()).To(BeEmpty())
+			Expect(tracker.selectors).To(BeEmpty())
+		})
+	})
+
+	It("should not track resources before controller is set", func() {
+		tracker.SetController(nil)
+		tracker.Track(sb1, []pipeline.ResourceReference{
+			{GroupVersionResource: secretsGVR, Namespace: "ns1", Name: "s1"},
+		})
+
+		Expect(reviews).To(Equal(0))
+		Expect(dependents(secretsGVR, "ns1", "s1")).To(BeEmpty())
+	})
+})
+
+type fakeController struct {
+	watches int
+}
+
+func (f *fakeController) Reconcile(context.Context, reconcile.Request) (reconcile.Result, error) {
+	return reconcile.Result{}, nil
+}
+
+func (f *fakeController) Watch(source.Source, handler.EventHandler, ...predicate.Predicate) error {
+	f.watches++
+	return nil
+}
+
+func (f *fakeController) Start(context.Context) error {
+	return nil
+}
+
+func (f *fakeController) GetLogger() logr.Logger {
+	return logr.Discard()
+}